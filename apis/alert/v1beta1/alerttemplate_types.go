@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// TemplateParameter declares a parameter of an AlertTemplate.
+type TemplateParameter struct {
+	// Name of the parameter, referenced as ${name} in string fields of the
+	// template. The built-in ${instance} parameter holds the instance name.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Default is used by instances that don't set the parameter. A
+	// parameter without a default must be set by every instance.
+	// +optional
+	Default *string `json:"default,omitempty"`
+}
+
+// AlertTemplateInstance is one Alert rendered from an AlertTemplate.
+type AlertTemplateInstance struct {
+	// Name identifies the instance. Its Alert is named
+	// <template name>-<instance name>.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Parameters are the instance's parameter values.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// AlertTemplateMetadata is the metadata applied to every rendered Alert.
+type AlertTemplateMetadata struct {
+	// Labels are added to each rendered Alert. Parameters are substituted
+	// in values.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are added to each rendered Alert. Parameters are
+	// substituted in values.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// AlertTemplateAlert is the Alert rendered for each instance.
+type AlertTemplateAlert struct {
+	// Metadata is applied to every rendered Alert.
+	// +optional
+	Metadata AlertTemplateMetadata `json:"metadata,omitempty"`
+
+	// Spec is the spec of every rendered Alert. Parameters are substituted
+	// in all string fields; write $${ for a literal ${.
+	Spec AlertSpec `json:"spec"`
+}
+
+// AlertTemplateSpec defines the desired state of an AlertTemplate.
+type AlertTemplateSpec struct {
+	// Parameters declares the parameters the template uses.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+
+	// Template is the Alert rendered for each instance.
+	Template AlertTemplateAlert `json:"template"`
+
+	// Instances lists instances and their parameter values.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Instances []AlertTemplateInstance `json:"instances,omitempty"`
+
+	// InstanceSelector selects ConfigMaps in the AlertTemplate's namespace
+	// that each define an instance in addition to Instances: the
+	// ConfigMap's name is the instance name and its data holds the
+	// parameter values.
+	// +optional
+	InstanceSelector *metav1.LabelSelector `json:"instanceSelector,omitempty"`
+}
+
+// TemplatedAlert reports one Alert rendered from an AlertTemplate.
+type TemplatedAlert struct {
+	// Instance is the name of the instance.
+	Instance string `json:"instance"`
+
+	// Name is the name of the rendered Alert. Empty if the instance could
+	// not be rendered.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Ready reports whether the Alert is Ready.
+	Ready bool `json:"ready"`
+
+	// Message explains why the Alert is not ready, or why the instance
+	// could not be rendered.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// AlertTemplateStatus represents the observed state of an AlertTemplate.
+type AlertTemplateStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// Instances is the number of instances, including any that could not
+	// be rendered.
+	// +optional
+	Instances int `json:"instances,omitempty"`
+
+	// ReadyAlerts is the number of rendered Alerts that are Ready.
+	// +optional
+	ReadyAlerts int `json:"readyAlerts,omitempty"`
+
+	// Alerts reports each instance's Alert, ordered by instance name.
+	// +optional
+	Alerts []TemplatedAlert `json:"alerts,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertTemplate renders one Alert per instance from a parameterised Alert
+// spec, and prunes the Alerts of instances that are removed.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCES",type="integer",JSONPath=".status.instances"
+// +kubebuilder:printcolumn:name="READY-ALERTS",type="integer",JSONPath=".status.readyAlerts"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,signoz}
+type AlertTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              AlertTemplateSpec   `json:"spec"`
+	Status            AlertTemplateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertTemplateList contains a list of AlertTemplates
+type AlertTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertTemplate `json:"items"`
+}
+
+// AlertTemplate type metadata.
+var (
+	AlertTemplate_Kind             = "AlertTemplate"
+	AlertTemplate_GroupKind        = schema.GroupKind{Group: Group, Kind: AlertTemplate_Kind}.String()
+	AlertTemplate_KindAPIVersion   = AlertTemplate_Kind + "." + SchemeGroupVersion.String()
+	AlertTemplate_GroupVersionKind = SchemeGroupVersion.WithKind(AlertTemplate_Kind)
+)
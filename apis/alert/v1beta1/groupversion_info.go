@@ -41,6 +41,10 @@ func addKnownTypes(s *runtime.Scheme) error {
 	s.AddKnownTypes(SchemeGroupVersion,
 		&Alert{},
 		&AlertList{},
+		&AlertTemplate{},
+		&AlertTemplateList{},
+		&PlannedMaintenance{},
+		&PlannedMaintenanceList{},
 	)
 	metav1.AddToGroupVersion(s, SchemeGroupVersion)
 	return nil
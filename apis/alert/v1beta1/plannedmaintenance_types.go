@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MaintenanceRecurrence repeats a maintenance window.
+type MaintenanceRecurrence struct {
+	// Duration is how long each window lasts, e.g. "2h" or "30m".
+	// +kubebuilder:validation:Required
+	Duration string `json:"duration"`
+
+	// RepeatType is how often the window repeats.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=daily;weekly;monthly
+	RepeatType string `json:"repeatType"`
+
+	// RepeatOn lists the weekdays a weekly window starts on. Defaults to
+	// the weekday of StartTime.
+	// +optional
+	// +kubebuilder:validation:items:Enum=sunday;monday;tuesday;wednesday;thursday;friday;saturday
+	RepeatOn []string `json:"repeatOn,omitempty"`
+}
+
+// MaintenanceSchedule is when a maintenance window is in effect.
+type MaintenanceSchedule struct {
+	// Timezone is the IANA time zone StartTime and EndTime are in, e.g.
+	// "Europe/London".
+	// +kubebuilder:validation:Required
+	Timezone string `json:"timezone"`
+
+	// StartTime is the local time the window, or the first recurring
+	// window, starts. Format: "2025-01-31T22:00" or "2025-01-31T22:00:00".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2})?$`
+	StartTime string `json:"startTime"`
+
+	// EndTime is the local time a one-off window ends, or the time after
+	// which a recurring window no longer repeats. Required for one-off
+	// windows; recurring windows without it repeat indefinitely.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}(:\d{2})?$`
+	EndTime string `json:"endTime,omitempty"`
+
+	// Recurrence repeats the window. Omit it for a one-off window.
+	// +optional
+	Recurrence *MaintenanceRecurrence `json:"recurrence,omitempty"`
+}
+
+// PlannedMaintenanceParameters are the configurable fields of a
+// PlannedMaintenance. Exactly one way of choosing the silenced alerts must
+// be used: AllAlerts, or any combination of AlertIDs, AlertRefs and
+// AlertSelector.
+type PlannedMaintenanceParameters struct {
+	// Name of the maintenance window.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Description of the maintenance window.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Schedule is when the maintenance window is in effect.
+	// +kubebuilder:validation:Required
+	Schedule MaintenanceSchedule `json:"schedule"`
+
+	// AlertIDs are the SigNoz IDs of alert rules silenced during the
+	// window.
+	// +optional
+	AlertIDs []string `json:"alertIds,omitempty"`
+
+	// AlertRefs are references to Alerts in the same namespace silenced
+	// during the window.
+	// +optional
+	AlertRefs []xpv1.Reference `json:"alertRefs,omitempty"`
+
+	// AlertSelector selects Alerts in the same namespace silenced during
+	// the window. A selector that matches no Alert is an error.
+	// +optional
+	AlertSelector *metav1.LabelSelector `json:"alertSelector,omitempty"`
+
+	// AllAlerts silences every alert rule during the window.
+	// +optional
+	AllAlerts bool `json:"allAlerts,omitempty"`
+}
+
+// PlannedMaintenanceSpec defines the desired state of a PlannedMaintenance.
+type PlannedMaintenanceSpec struct {
+	xpv1.ManagedResourceSpec `json:",inline"`
+	ForProvider              PlannedMaintenanceParameters `json:"forProvider"`
+	// ExternalChangePolicy determines whether edits made in SigNoz since
+	// the provider last applied this resource are overwritten or left
+	// alone. Changes to forProvider are always applied.
+	// +optional
+	// +kubebuilder:default=Overwrite
+	ExternalChangePolicy apisv1beta1.ExternalChangePolicy `json:"externalChangePolicy,omitempty"`
+}
+
+// PlannedMaintenanceObservation are the observable fields of a
+// PlannedMaintenance.
+type PlannedMaintenanceObservation struct {
+	// ID is the unique identifier of the maintenance window in SigNoz.
+	ID string `json:"id,omitempty"`
+
+	// Status is whether the window is active, upcoming or expired.
+	Status string `json:"status,omitempty"`
+
+	// Kind is fixed for one-off windows and recurring otherwise.
+	Kind string `json:"kind,omitempty"`
+
+	// ResolvedAlertIDs are the IDs of the alert rules silenced during the
+	// window. Empty when AllAlerts is set.
+	ResolvedAlertIDs []string `json:"resolvedAlertIds,omitempty"`
+
+	// CreatedAt is when the maintenance window was created.
+	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
+
+	// UpdatedAt is when the maintenance window was last updated.
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// LastDrift summarises the most recent difference found between the
+	// desired and observed state, which triggered an update.
+	// +optional
+	LastDrift *apisv1beta1.DriftSummary `json:"lastDrift,omitempty"`
+}
+
+// PlannedMaintenanceStatus represents the observed state of a
+// PlannedMaintenance.
+type PlannedMaintenanceStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+	AtProvider             PlannedMaintenanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+
+// PlannedMaintenance is a SigNoz downtime schedule, which silences alert
+// rules while it is in effect.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".status.atProvider.kind"
+// +kubebuilder:printcolumn:name="START",type="string",JSONPath=".spec.forProvider.schedule.startTime",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,signoz}
+type PlannedMaintenance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              PlannedMaintenanceSpec   `json:"spec"`
+	Status            PlannedMaintenanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PlannedMaintenanceList contains a list of PlannedMaintenances
+type PlannedMaintenanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PlannedMaintenance `json:"items"`
+}
+
+// PlannedMaintenance type metadata.
+var (
+	PlannedMaintenance_Kind             = "PlannedMaintenance"
+	PlannedMaintenance_GroupKind        = schema.GroupKind{Group: Group, Kind: PlannedMaintenance_Kind}.String()
+	PlannedMaintenance_KindAPIVersion   = PlannedMaintenance_Kind + "." + SchemeGroupVersion.String()
+	PlannedMaintenance_GroupVersionKind = SchemeGroupVersion.WithKind(PlannedMaintenance_Kind)
+)
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -70,7 +71,7 @@ type AlertParameters struct {
 
 	// ChannelIDsSelector selects NotificationChannels by labels.
 	// +optional
-	ChannelIDsSelector *xpv1.Selector `json:"channelIdsSelector,omitempty"`
+	ChannelIDsSelector *ChannelSelector `json:"channelIdsSelector,omitempty"`
 
 	// Disabled indicates if the alert is disabled.
 	// +optional
@@ -105,6 +106,12 @@ type RuleCondition struct {
 	// silently gets wrong for any rule created with multi-level thresholds.
 	// +optional
 	Thresholds []Threshold `json:"thresholds,omitempty"`
+
+	// SelectedQueryName is the query or formula the condition is evaluated
+	// against. Defaults to the last formula when Formulas are set, and to
+	// "A" otherwise.
+	// +optional
+	SelectedQueryName string `json:"selectedQueryName,omitempty"`
 }
 
 // Threshold defines a single severity level within a v5 multi-level
@@ -139,6 +146,36 @@ type Threshold struct {
 	// level.
 	// +optional
 	Channels []string `json:"channels,omitempty"`
+
+	// ChannelRefs are references to NotificationChannel resources to notify
+	// for this severity level, in addition to Channels.
+	// +optional
+	ChannelRefs []xpv1.Reference `json:"channelRefs,omitempty"`
+
+	// ChannelSelector selects NotificationChannels by labels to notify for
+	// this severity level, in addition to Channels.
+	// +optional
+	ChannelSelector *ChannelSelector `json:"channelSelector,omitempty"`
+}
+
+// ChannelSelector selects NotificationChannels in the Alert's namespace. It
+// extends the standard Crossplane selector with set-based label
+// requirements. All criteria that are set must match, and at least one must
+// be set: an empty selector is rejected rather than selecting every channel.
+type ChannelSelector struct {
+	// MatchLabels ensures an object with matching labels is selected.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+
+	// MatchExpressions ensures an object whose labels satisfy every
+	// requirement is selected.
+	// +optional
+	MatchExpressions []metav1.LabelSelectorRequirement `json:"matchExpressions,omitempty"`
+
+	// MatchControllerRef ensures an object with the same controller reference
+	// as the selecting object is selected.
+	// +optional
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
 }
 
 // CompositeQuery defines a composite query for alerts.
@@ -160,6 +197,17 @@ type CompositeQuery struct {
 	// +optional
 	Builder *QueryBuilder `json:"builder,omitempty"`
 
+	// Builders contains further builder queries, sent after Builder.
+	// Queries without a QueryName are named by their position across
+	// Builder and Builders: A, B, C and so on.
+	// +optional
+	Builders []QueryBuilder `json:"builders,omitempty"`
+
+	// Formulas combine builder queries arithmetically, e.g. F1 = A/B*100
+	// for an error ratio.
+	// +optional
+	Formulas []QueryFormula `json:"formulas,omitempty"`
+
 	// Expression combines multiple queries with mathematical operations.
 	// +optional
 	Expression string `json:"expression,omitempty"`
@@ -174,6 +222,28 @@ type CompositeQuery struct {
 	Unit string `json:"unit,omitempty"`
 }
 
+// QueryFormula defines a v5 builder formula over named builder queries.
+type QueryFormula struct {
+	// Name is the formula identifier (e.g. "F1").
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Expression is the arithmetic over builder query names, e.g.
+	// "A/B*100".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+
+	// Legend is an optional legend format.
+	// +optional
+	Legend string `json:"legend,omitempty"`
+
+	// Disabled indicates if this formula is disabled.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
 // AlertPromQuery defines a PromQL query for alerts.
 type AlertPromQuery struct {
 	// Query is the PromQL query string.
@@ -338,7 +408,9 @@ type KeyAttribute struct {
 	DataType string `json:"dataType,omitempty"`
 }
 
-// FilterSet defines a set of filters.
+// FilterSet defines a set of filters. It is compiled to a SigNoz v5 filter
+// expression: Items are joined by Operator, followed by each of Groups in
+// parentheses.
 type FilterSet struct {
 	// Operator is the logical operator (AND, OR).
 	// +kubebuilder:validation:Enum=AND;OR
@@ -346,19 +418,60 @@ type FilterSet struct {
 
 	// Items are the filter conditions.
 	Items []FilterItem `json:"items"`
+
+	// Groups are nested groups of filter conditions, each combined with
+	// the Items using Operator. Groups may themselves hold groups, nested
+	// at most 4 deep.
+	// +optional
+	Groups []FilterGroup `json:"groups,omitempty"`
+}
+
+// FilterGroup defines a parenthesised group of filter conditions.
+type FilterGroup struct {
+	// Operator is the logical operator joining the group's items (AND, OR).
+	// +kubebuilder:validation:Enum=AND;OR
+	Operator string `json:"operator"`
+
+	// Not negates the group.
+	// +optional
+	Not bool `json:"not,omitempty"`
+
+	// Items are the filter conditions.
+	// +optional
+	Items []FilterItem `json:"items,omitempty"`
+
+	// Groups are nested groups, each combined with the Items using
+	// Operator. A CRD schema can't be recursive, so these are validated
+	// when the filter is compiled rather than on admission.
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Groups []FilterGroup `json:"groups,omitempty"`
 }
 
 // FilterItem defines a single filter condition.
 type FilterItem struct {
-	// Key is the attribute to filter on.
+	// Key is the attribute to filter on. Key.DataType selects how the value
+	// is written: int64, float64 and number values are emitted as numbers,
+	// bool values as true/false, and anything else as a quoted string.
 	Key KeyAttribute `json:"key"`
 
-	// Op is the comparison operator.
+	// Op is the comparison operator: =, !=, <, <=, >, >=, LIKE, NOT LIKE,
+	// ILIKE, NOT ILIKE, CONTAINS, NOT CONTAINS, REGEXP, NOT REGEXP, IN,
+	// NOT IN, BETWEEN, NOT BETWEEN, EXISTS or NOT EXISTS. The query
+	// builder's short forms (in, nin, nlike, regex, nexists, ...) are also
+	// accepted.
 	Op string `json:"op"`
 
-	// Value is the filter value.
+	// Value is the filter value. It is required by every operator except
+	// EXISTS/NOT EXISTS and the list operators.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Value *string `json:"value,omitempty"`
+
+	// Values are the operands of IN/NOT IN, or the lower and upper bound of
+	// BETWEEN/NOT BETWEEN.
+	// +optional
+	Values []string `json:"values,omitempty"`
 }
 
 // Having defines a post-aggregation filter.
@@ -388,6 +501,12 @@ type OrderBy struct {
 type AlertSpec struct {
 	xpv1.ManagedResourceSpec `json:",inline"`
 	ForProvider              AlertParameters `json:"forProvider"`
+	// ExternalChangePolicy determines whether edits made in SigNoz since
+	// the provider last applied this resource are overwritten or left
+	// alone. Changes to forProvider are always applied.
+	// +optional
+	// +kubebuilder:default=Overwrite
+	ExternalChangePolicy apisv1beta1.ExternalChangePolicy `json:"externalChangePolicy,omitempty"`
 }
 
 // AlertObservation are the observable fields of an Alert.
@@ -395,12 +514,29 @@ type AlertObservation struct {
 	// ID is the unique identifier of the alert in SigNoz.
 	ID string `json:"id,omitempty"`
 
-	// State is the current state of the alert (inactive, pending, firing, etc.).
+	// State is the current state of the alert (inactive, pending, firing,
+	// nodata, disabled), taken from the rule's state history and falling
+	// back to the state reported on the rule itself.
 	State string `json:"state,omitempty"`
 
-	// LastFiredTime is when the alert last fired.
+	// LastFiredTime is when the alert last started firing.
 	LastFiredTime *metav1.Time `json:"lastFiredTime,omitempty"`
 
+	// LastResolvedTime is when the alert last went from firing back to
+	// inactive.
+	LastResolvedTime *metav1.Time `json:"lastResolvedTime,omitempty"`
+
+	// FiringSeries is the number of series of the alert currently firing.
+	FiringSeries int `json:"firingSeries,omitempty"`
+
+	// TopFiringSeries lists the label sets of the currently firing series,
+	// most recently fired first. Bounded to a handful of entries.
+	TopFiringSeries []FiringSeries `json:"topFiringSeries,omitempty"`
+
+	// HistoryObservedAt is when the rule's state history was last queried.
+	// It is queried at most once per poll interval.
+	HistoryObservedAt *metav1.Time `json:"historyObservedAt,omitempty"`
+
 	// CreatedAt is when the alert was created.
 	CreatedAt *metav1.Time `json:"createdAt,omitempty"`
 
@@ -409,6 +545,35 @@ type AlertObservation struct {
 
 	// ResolvedChannelIDs contains the IDs of resolved notification channels.
 	ResolvedChannelIDs []string `json:"resolvedChannelIds,omitempty"`
+
+	// ResolvedThresholdChannels contains the notification channel names
+	// resolved for each threshold that uses ChannelRefs or ChannelSelector.
+	ResolvedThresholdChannels []ThresholdChannels `json:"resolvedThresholdChannels,omitempty"`
+
+	// LastDrift summarises the most recent difference found between the
+	// desired and observed state, which triggered an update.
+	// +optional
+	LastDrift *apisv1beta1.DriftSummary `json:"lastDrift,omitempty"`
+}
+
+// ThresholdChannels are the notification channels resolved for one
+// threshold.
+type ThresholdChannels struct {
+	// Name is the name of the threshold.
+	Name string `json:"name"`
+
+	// Channels are the resolved notification channel names, including any
+	// listed directly in the threshold's Channels.
+	Channels []string `json:"channels,omitempty"`
+}
+
+// FiringSeries identifies one firing series of an Alert.
+type FiringSeries struct {
+	// Labels is the label set identifying the series.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Since is when the series started firing.
+	Since *metav1.Time `json:"since,omitempty"`
 }
 
 // AlertStatus represents the observed state of an Alert.
@@ -428,6 +593,9 @@ type AlertStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="FIRING",type="integer",JSONPath=".status.atProvider.firingSeries"
+// +kubebuilder:printcolumn:name="LAST-FIRED",type="date",JSONPath=".status.atProvider.lastFiredTime"
+// +kubebuilder:printcolumn:name="LAST-RESOLVED",type="date",JSONPath=".status.atProvider.lastResolvedTime",priority=1
 // +kubebuilder:printcolumn:name="SEVERITY",type="string",JSONPath=".spec.forProvider.severity"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
@@ -22,6 +22,8 @@ package v1beta1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -106,6 +108,21 @@ func (in *AlertObservation) DeepCopyInto(out *AlertObservation) {
 		in, out := &in.LastFiredTime, &out.LastFiredTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastResolvedTime != nil {
+		in, out := &in.LastResolvedTime, &out.LastResolvedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.TopFiringSeries != nil {
+		in, out := &in.TopFiringSeries, &out.TopFiringSeries
+		*out = make([]FiringSeries, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HistoryObservedAt != nil {
+		in, out := &in.HistoryObservedAt, &out.HistoryObservedAt
+		*out = (*in).DeepCopy()
+	}
 	if in.CreatedAt != nil {
 		in, out := &in.CreatedAt, &out.CreatedAt
 		*out = (*in).DeepCopy()
@@ -119,6 +136,18 @@ func (in *AlertObservation) DeepCopyInto(out *AlertObservation) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResolvedThresholdChannels != nil {
+		in, out := &in.ResolvedThresholdChannels, &out.ResolvedThresholdChannels
+		*out = make([]ThresholdChannels, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastDrift != nil {
+		in, out := &in.LastDrift, &out.LastDrift
+		*out = new(apisv1beta1.DriftSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertObservation.
@@ -163,7 +192,7 @@ func (in *AlertParameters) DeepCopyInto(out *AlertParameters) {
 	}
 	if in.ChannelIDsSelector != nil {
 		in, out := &in.ChannelIDsSelector, &out.ChannelIDsSelector
-		*out = new(v2.Selector)
+		*out = new(ChannelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -227,6 +256,223 @@ func (in *AlertStatus) DeepCopy() *AlertStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplate) DeepCopyInto(out *AlertTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplate.
+func (in *AlertTemplate) DeepCopy() *AlertTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateAlert) DeepCopyInto(out *AlertTemplateAlert) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateAlert.
+func (in *AlertTemplateAlert) DeepCopy() *AlertTemplateAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateInstance) DeepCopyInto(out *AlertTemplateInstance) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateInstance.
+func (in *AlertTemplateInstance) DeepCopy() *AlertTemplateInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateList) DeepCopyInto(out *AlertTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateList.
+func (in *AlertTemplateList) DeepCopy() *AlertTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateMetadata) DeepCopyInto(out *AlertTemplateMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateMetadata.
+func (in *AlertTemplateMetadata) DeepCopy() *AlertTemplateMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateSpec) DeepCopyInto(out *AlertTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]AlertTemplateInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstanceSelector != nil {
+		in, out := &in.InstanceSelector, &out.InstanceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateSpec.
+func (in *AlertTemplateSpec) DeepCopy() *AlertTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertTemplateStatus) DeepCopyInto(out *AlertTemplateStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Alerts != nil {
+		in, out := &in.Alerts, &out.Alerts
+		*out = make([]TemplatedAlert, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertTemplateStatus.
+func (in *AlertTemplateStatus) DeepCopy() *AlertTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChannelSelector) DeepCopyInto(out *ChannelSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MatchExpressions != nil {
+		in, out := &in.MatchExpressions, &out.MatchExpressions
+		*out = make([]v1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchControllerRef != nil {
+		in, out := &in.MatchControllerRef, &out.MatchControllerRef
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChannelSelector.
+func (in *ChannelSelector) DeepCopy() *ChannelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ChannelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompositeQuery) DeepCopyInto(out *CompositeQuery) {
 	*out = *in
@@ -245,6 +491,18 @@ func (in *CompositeQuery) DeepCopyInto(out *CompositeQuery) {
 		*out = new(QueryBuilder)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Builders != nil {
+		in, out := &in.Builders, &out.Builders
+		*out = make([]QueryBuilder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Formulas != nil {
+		in, out := &in.Formulas, &out.Formulas
+		*out = make([]QueryFormula, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeQuery.
@@ -257,6 +515,35 @@ func (in *CompositeQuery) DeepCopy() *CompositeQuery {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterGroup) DeepCopyInto(out *FilterGroup) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FilterItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]FilterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterGroup.
+func (in *FilterGroup) DeepCopy() *FilterGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FilterItem) DeepCopyInto(out *FilterItem) {
 	*out = *in
@@ -266,6 +553,11 @@ func (in *FilterItem) DeepCopyInto(out *FilterItem) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterItem.
@@ -288,6 +580,13 @@ func (in *FilterSet) DeepCopyInto(out *FilterSet) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]FilterGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterSet.
@@ -300,6 +599,32 @@ func (in *FilterSet) DeepCopy() *FilterSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FiringSeries) DeepCopyInto(out *FiringSeries) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FiringSeries.
+func (in *FiringSeries) DeepCopy() *FiringSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(FiringSeries)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Having) DeepCopyInto(out *Having) {
 	*out = *in
@@ -335,6 +660,46 @@ func (in *KeyAttribute) DeepCopy() *KeyAttribute {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceRecurrence) DeepCopyInto(out *MaintenanceRecurrence) {
+	*out = *in
+	if in.RepeatOn != nil {
+		in, out := &in.RepeatOn, &out.RepeatOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceRecurrence.
+func (in *MaintenanceRecurrence) DeepCopy() *MaintenanceRecurrence {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceRecurrence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSchedule) DeepCopyInto(out *MaintenanceSchedule) {
+	*out = *in
+	if in.Recurrence != nil {
+		in, out := &in.Recurrence, &out.Recurrence
+		*out = new(MaintenanceRecurrence)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSchedule.
+func (in *MaintenanceSchedule) DeepCopy() *MaintenanceSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OrderBy) DeepCopyInto(out *OrderBy) {
 	*out = *in
@@ -350,6 +715,165 @@ func (in *OrderBy) DeepCopy() *OrderBy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenance) DeepCopyInto(out *PlannedMaintenance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenance.
+func (in *PlannedMaintenance) DeepCopy() *PlannedMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PlannedMaintenance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenanceList) DeepCopyInto(out *PlannedMaintenanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PlannedMaintenance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenanceList.
+func (in *PlannedMaintenanceList) DeepCopy() *PlannedMaintenanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PlannedMaintenanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenanceObservation) DeepCopyInto(out *PlannedMaintenanceObservation) {
+	*out = *in
+	if in.ResolvedAlertIDs != nil {
+		in, out := &in.ResolvedAlertIDs, &out.ResolvedAlertIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.UpdatedAt != nil {
+		in, out := &in.UpdatedAt, &out.UpdatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastDrift != nil {
+		in, out := &in.LastDrift, &out.LastDrift
+		*out = new(apisv1beta1.DriftSummary)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenanceObservation.
+func (in *PlannedMaintenanceObservation) DeepCopy() *PlannedMaintenanceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenanceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenanceParameters) DeepCopyInto(out *PlannedMaintenanceParameters) {
+	*out = *in
+	in.Schedule.DeepCopyInto(&out.Schedule)
+	if in.AlertIDs != nil {
+		in, out := &in.AlertIDs, &out.AlertIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertRefs != nil {
+		in, out := &in.AlertRefs, &out.AlertRefs
+		*out = make([]v2.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AlertSelector != nil {
+		in, out := &in.AlertSelector, &out.AlertSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenanceParameters.
+func (in *PlannedMaintenanceParameters) DeepCopy() *PlannedMaintenanceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenanceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenanceSpec) DeepCopyInto(out *PlannedMaintenanceSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenanceSpec.
+func (in *PlannedMaintenanceSpec) DeepCopy() *PlannedMaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedMaintenanceStatus) DeepCopyInto(out *PlannedMaintenanceStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedMaintenanceStatus.
+func (in *PlannedMaintenanceStatus) DeepCopy() *PlannedMaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedMaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueryBuilder) DeepCopyInto(out *QueryBuilder) {
 	*out = *in
@@ -412,6 +936,21 @@ func (in *QueryBuilder) DeepCopy() *QueryBuilder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryFormula) DeepCopyInto(out *QueryFormula) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryFormula.
+func (in *QueryFormula) DeepCopy() *QueryFormula {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryFormula)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RuleCondition) DeepCopyInto(out *RuleCondition) {
 	*out = *in
@@ -445,6 +984,41 @@ func (in *RuleCondition) DeepCopy() *RuleCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatedAlert) DeepCopyInto(out *TemplatedAlert) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatedAlert.
+func (in *TemplatedAlert) DeepCopy() *TemplatedAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatedAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Threshold) DeepCopyInto(out *Threshold) {
 	*out = *in
@@ -458,6 +1032,18 @@ func (in *Threshold) DeepCopyInto(out *Threshold) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ChannelRefs != nil {
+		in, out := &in.ChannelRefs, &out.ChannelRefs
+		*out = make([]v2.Reference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ChannelSelector != nil {
+		in, out := &in.ChannelSelector, &out.ChannelSelector
+		*out = new(ChannelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Threshold.
@@ -469,3 +1055,23 @@ func (in *Threshold) DeepCopy() *Threshold {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ThresholdChannels) DeepCopyInto(out *ThresholdChannels) {
+	*out = *in
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ThresholdChannels.
+func (in *ThresholdChannels) DeepCopy() *ThresholdChannels {
+	if in == nil {
+		return nil
+	}
+	out := new(ThresholdChannels)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -72,3 +72,53 @@ func (mg *Alert) GetExternalName() string {
 func (mg *Alert) SetExternalName(name string) {
 	meta.SetExternalName(mg, name)
 }
+
+// GetCondition of this PlannedMaintenance.
+func (mg *PlannedMaintenance) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetManagementPolicies of this PlannedMaintenance.
+func (mg *PlannedMaintenance) GetManagementPolicies() xpv1.ManagementPolicies {
+	return mg.Spec.ManagementPolicies
+}
+
+// GetProviderConfigReference of this PlannedMaintenance.
+func (mg *PlannedMaintenance) GetProviderConfigReference() *xpv1.ProviderConfigReference {
+	return mg.Spec.ProviderConfigReference
+}
+
+// GetWriteConnectionSecretToReference of this PlannedMaintenance.
+func (mg *PlannedMaintenance) GetWriteConnectionSecretToReference() *xpv1.LocalSecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this PlannedMaintenance.
+func (mg *PlannedMaintenance) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetManagementPolicies of this PlannedMaintenance.
+func (mg *PlannedMaintenance) SetManagementPolicies(r xpv1.ManagementPolicies) {
+	mg.Spec.ManagementPolicies = r
+}
+
+// SetProviderConfigReference of this PlannedMaintenance.
+func (mg *PlannedMaintenance) SetProviderConfigReference(r *xpv1.ProviderConfigReference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+// SetWriteConnectionSecretToReference of this PlannedMaintenance.
+func (mg *PlannedMaintenance) SetWriteConnectionSecretToReference(r *xpv1.LocalSecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetExternalName returns the external name of this PlannedMaintenance.
+func (mg *PlannedMaintenance) GetExternalName() string {
+	return meta.GetExternalName(mg)
+}
+
+// SetExternalName sets the external name of this PlannedMaintenance.
+func (mg *PlannedMaintenance) SetExternalName(name string) {
+	meta.SetExternalName(mg, name)
+}
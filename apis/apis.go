@@ -20,6 +20,7 @@ import (
 	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
 	channelv1beta1 "github.com/rossigee/provider-signoz/apis/channel/v1beta1"
 	dashboardv1beta1 "github.com/rossigee/provider-signoz/apis/dashboard/v1beta1"
+	slov1beta1 "github.com/rossigee/provider-signoz/apis/slo/v1beta1"
 	v1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -31,6 +32,7 @@ func init() {
 		alertv1beta1.SchemeBuilder.AddToScheme,
 		channelv1beta1.SchemeBuilder.AddToScheme,
 		dashboardv1beta1.SchemeBuilder.AddToScheme,
+		slov1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
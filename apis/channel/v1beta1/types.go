@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -227,6 +228,12 @@ type SNSConfig struct {
 type NotificationChannelSpec struct {
 	xpv1.ManagedResourceSpec `json:",inline"`
 	ForProvider              NotificationChannelParameters `json:"forProvider"`
+	// ExternalChangePolicy determines whether edits made in SigNoz since
+	// the provider last applied this resource are overwritten or left
+	// alone. Changes to forProvider are always applied.
+	// +optional
+	// +kubebuilder:default=Overwrite
+	ExternalChangePolicy apisv1beta1.ExternalChangePolicy `json:"externalChangePolicy,omitempty"`
 }
 
 // NotificationChannelObservation are the observable fields of a NotificationChannel.
@@ -239,6 +246,11 @@ type NotificationChannelObservation struct {
 
 	// UpdatedAt is when the channel was last updated.
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// LastDrift summarises the most recent difference found between the
+	// desired and observed state, which triggered an update.
+	// +optional
+	LastDrift *apisv1beta1.DriftSummary `json:"lastDrift,omitempty"`
 }
 
 // NotificationChannelStatus represents the observed state of a NotificationChannel.
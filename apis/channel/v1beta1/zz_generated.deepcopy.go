@@ -22,6 +22,7 @@ package v1beta1
 
 import (
 	"github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -155,6 +156,11 @@ func (in *NotificationChannelObservation) DeepCopyInto(out *NotificationChannelO
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.LastDrift != nil {
+		in, out := &in.LastDrift, &out.LastDrift
+		*out = new(apisv1beta1.DriftSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelObservation.
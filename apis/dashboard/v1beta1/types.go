@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -253,6 +254,12 @@ type Variable struct {
 type DashboardSpec struct {
 	xpv1.ManagedResourceSpec `json:",inline"`
 	ForProvider              DashboardParameters `json:"forProvider"`
+	// ExternalChangePolicy determines whether edits made in SigNoz since
+	// the provider last applied this resource are overwritten or left
+	// alone. Changes to forProvider are always applied.
+	// +optional
+	// +kubebuilder:default=Overwrite
+	ExternalChangePolicy apisv1beta1.ExternalChangePolicy `json:"externalChangePolicy,omitempty"`
 }
 
 // DashboardObservation are the observable fields of a Dashboard.
@@ -268,6 +275,11 @@ type DashboardObservation struct {
 
 	// UpdatedAt is the timestamp when the dashboard was last updated.
 	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+
+	// LastDrift summarises the most recent difference found between the
+	// desired and observed state, which triggered an update.
+	// +optional
+	LastDrift *apisv1beta1.DriftSummary `json:"lastDrift,omitempty"`
 }
 
 // DashboardStatus represents the observed state of a Dashboard.
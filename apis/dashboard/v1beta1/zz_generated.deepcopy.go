@@ -21,6 +21,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -119,6 +120,11 @@ func (in *DashboardObservation) DeepCopyInto(out *DashboardObservation) {
 		in, out := &in.UpdatedAt, &out.UpdatedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.LastDrift != nil {
+		in, out := &in.LastDrift, &out.LastDrift
+		*out = new(apisv1beta1.DriftSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardObservation.
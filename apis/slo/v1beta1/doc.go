@@ -0,0 +1,6 @@
+// +kubebuilder:object:generate=true
+// +groupName=slo.signoz.m.crossplane.io
+// +versionName=v1beta1
+
+// Package v1beta1 contains the v1beta1 group slo.signoz.m.crossplane.io resources of provider-signoz.
+package v1beta1
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PromQLIndicator measures the SLI with two PromQL queries. Each query
+// must contain ${window}, which is replaced with the range being evaluated
+// (e.g. 5m, 1h, 30d), as in
+// sum(rate(http_requests_total{code!~"5.."}[${window}])).
+type PromQLIndicator struct {
+	// Good is the rate of good events.
+	// +kubebuilder:validation:MinLength=1
+	Good string `json:"good"`
+
+	// Total is the rate of all events.
+	// +kubebuilder:validation:MinLength=1
+	Total string `json:"total"`
+}
+
+// Indicator is the service level indicator: the ratio of good events to
+// all events.
+type Indicator struct {
+	// PromQL measures the indicator with PromQL queries.
+	PromQL *PromQLIndicator `json:"promQL"`
+}
+
+// SLOAlerting configures the generated burn-rate Alerts.
+type SLOAlerting struct {
+	// FastBurnSeverity is the severity of the fast-burn (1h/5m) Alert.
+	// +optional
+	// +kubebuilder:default=critical
+	// +kubebuilder:validation:Enum=info;warning;error;critical
+	FastBurnSeverity string `json:"fastBurnSeverity,omitempty"`
+
+	// SlowBurnSeverity is the severity of the slow-burn (6h/30m) Alert.
+	// +optional
+	// +kubebuilder:default=warning
+	// +kubebuilder:validation:Enum=info;warning;error;critical
+	SlowBurnSeverity string `json:"slowBurnSeverity,omitempty"`
+
+	// Labels are added to both Alerts.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ChannelIDsRef references the NotificationChannels both Alerts notify.
+	// +optional
+	ChannelIDsRef []xpv1.Reference `json:"channelIdsRef,omitempty"`
+
+	// ChannelIDsSelector selects the NotificationChannels both Alerts
+	// notify.
+	// +optional
+	ChannelIDsSelector *alertv1beta1.ChannelSelector `json:"channelIdsSelector,omitempty"`
+}
+
+// ServiceLevelObjectiveSpec defines the desired state of a
+// ServiceLevelObjective.
+type ServiceLevelObjectiveSpec struct {
+	// DisplayName names the generated Alerts and Dashboard. Defaults to the
+	// object's name.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Target is the percentage of good events the service must deliver
+	// over Window, e.g. 99.9.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:ExclusiveMinimum=true
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:validation:ExclusiveMaximum=true
+	Target float64 `json:"target"`
+
+	// Window is the rolling period the target applies to, in hours or
+	// days, e.g. 30d.
+	// +optional
+	// +kubebuilder:default="30d"
+	// +kubebuilder:validation:Pattern=`^[1-9][0-9]*[hd]$`
+	Window string `json:"window,omitempty"`
+
+	// Indicator measures good and total events.
+	Indicator Indicator `json:"indicator"`
+
+	// Alerting configures the generated burn-rate Alerts.
+	// +optional
+	Alerting SLOAlerting `json:"alerting,omitempty"`
+
+	// ProviderConfigReference is passed to the generated Alerts and
+	// Dashboard, and used to query the error budget.
+	// +optional
+	// +kubebuilder:default={"kind": "ClusterProviderConfig", "name": "default"}
+	ProviderConfigReference *xpv1.ProviderConfigReference `json:"providerConfigRef,omitempty"`
+}
+
+// ServiceLevelObjectiveStatus represents the observed state of a
+// ServiceLevelObjective.
+type ServiceLevelObjectiveStatus struct {
+	xpv1.ConditionedStatus `json:",inline"`
+
+	// BurnRate is the rate the error budget is being spent at over the last
+	// hour: 1 spends exactly the budget over the window.
+	// +optional
+	BurnRate *float64 `json:"burnRate,omitempty"`
+
+	// ErrorBudgetRemaining is the percentage of the window's error budget
+	// left. It is negative once the budget is exhausted.
+	// +optional
+	ErrorBudgetRemaining *float64 `json:"errorBudgetRemaining,omitempty"`
+
+	// LastEvaluated is when BurnRate and ErrorBudgetRemaining were last
+	// queried.
+	// +optional
+	LastEvaluated *metav1.Time `json:"lastEvaluated,omitempty"`
+
+	// Alerts are the names of the generated Alerts.
+	// +optional
+	Alerts []string `json:"alerts,omitempty"`
+
+	// Dashboard is the name of the generated Dashboard.
+	// +optional
+	Dashboard string `json:"dashboard,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ServiceLevelObjective generates multi-window, multi-burn-rate Alerts and
+// an error budget Dashboard for a service level indicator, and reports how
+// much of the error budget is left.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TARGET",type="number",JSONPath=".spec.target"
+// +kubebuilder:printcolumn:name="BURN-RATE",type="number",JSONPath=".status.burnRate"
+// +kubebuilder:printcolumn:name="BUDGET-REMAINING",type="number",JSONPath=".status.errorBudgetRemaining"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,signoz},shortName=slo
+type ServiceLevelObjective struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ServiceLevelObjectiveSpec   `json:"spec"`
+	Status            ServiceLevelObjectiveStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceLevelObjectiveList contains a list of ServiceLevelObjectives
+type ServiceLevelObjectiveList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceLevelObjective `json:"items"`
+}
+
+// ServiceLevelObjective type metadata.
+var (
+	ServiceLevelObjective_Kind             = "ServiceLevelObjective"
+	ServiceLevelObjective_GroupKind        = schema.GroupKind{Group: Group, Kind: ServiceLevelObjective_Kind}.String()
+	ServiceLevelObjective_KindAPIVersion   = ServiceLevelObjective_Kind + "." + SchemeGroupVersion.String()
+	ServiceLevelObjective_GroupVersionKind = SchemeGroupVersion.WithKind(ServiceLevelObjective_Kind)
+)
@@ -38,6 +38,112 @@ type ProviderConfigSpec struct {
 	// +optional
 	// +kubebuilder:default=false
 	InsecureSkipTLSVerify *bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the SigNoz API's certificate is verified and the
+	// client certificate presented to it.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// Proxy configures an HTTP proxy to reach the SigNoz API through. When
+	// unset the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables
+	// of the provider are honoured.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// Headers are added to every request to the SigNoz API, e.g. for an
+	// API gateway in front of it. They can't replace the Content-Type or
+	// API key headers.
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// RateLimit limits the rate of requests to the SigNoz API. When SigNoz
+	// responds 429 Too Many Requests, requests to its endpoint are paused
+	// until the Retry-After deadline regardless.
+	// +optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// RateLimitConfig configures the token bucket requests to the SigNoz API
+// take a token from.
+type RateLimitConfig struct {
+	// QPS is the sustained number of requests per second.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=10
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the number of requests that can be made at once, above the
+	// sustained rate.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=20
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// ProxyConfig configures the HTTP proxy used to reach the SigNoz API.
+type ProxyConfig struct {
+	// URL of the proxy, e.g. http://proxy.example.com:3128.
+	// +kubebuilder:validation:Pattern=`^(http|https|socks5)://`
+	URL string `json:"url"`
+
+	// CredentialsSecretRef references a Secret of type
+	// kubernetes.io/basic-auth whose username and password authenticate
+	// to the proxy.
+	// +optional
+	CredentialsSecretRef *xpv1.SecretReference `json:"credentialsSecretRef,omitempty"`
+
+	// NoProxy lists hosts reached directly rather than through the proxy,
+	// in the format of the NO_PROXY environment variable: host names,
+	// domain suffixes such as .example.com, IP addresses and CIDRs.
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// TLSConfig configures TLS connections to the SigNoz API.
+type TLSConfig struct {
+	// CABundle is a PEM encoded bundle of CA certificates trusted to sign
+	// the SigNoz API's certificate, in addition to the system roots.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// CABundleSecretRef selects a key of a Secret holding a PEM encoded
+	// CA bundle.
+	// +optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// CABundleConfigMapRef selects a key of a ConfigMap holding a PEM
+	// encoded CA bundle.
+	// +optional
+	CABundleConfigMapRef *ConfigMapKeySelector `json:"caBundleConfigMapRef,omitempty"`
+
+	// ClientCertificateSecretRef references a Secret of type
+	// kubernetes.io/tls whose tls.crt and tls.key are presented to the
+	// SigNoz API for mutual TLS.
+	// +optional
+	ClientCertificateSecretRef *xpv1.SecretReference `json:"clientCertificateSecretRef,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted.
+	// +optional
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	// +kubebuilder:default="1.2"
+	MinVersion string `json:"minVersion,omitempty"`
+
+	// ServerName overrides the name used for SNI and to verify the SigNoz
+	// API's certificate, e.g. when the endpoint is an IP address.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Key whose value is selected.
+	Key string `json:"key"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -46,12 +152,112 @@ type ProviderCredentials struct {
 	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
 	Source xpv1.CredentialsSource `json:"source"`
 
+	// AuthMode selects how the credentials authenticate to the SigNoz API.
+	// APIKey credentials are {"apiKey": "..."}, optionally with a
+	// "nextApiKey" used when apiKey is rejected, for rotation; Password
+	// credentials are {"email": "...", "password": "..."}.
+	// +optional
+	// +kubebuilder:default=APIKey
+	AuthMode AuthMode `json:"authMode,omitempty"`
+
 	xpv1.CommonCredentialSelectors `json:",inline"`
 }
 
+// AuthMode is how the provider authenticates to the SigNoz API.
+// +kubebuilder:validation:Enum=APIKey;Password
+type AuthMode string
+
+const (
+	// AuthModeAPIKey sends the credentials' apiKey in the SIGNOZ-API-KEY
+	// header. This is the default.
+	AuthModeAPIKey AuthMode = "APIKey"
+
+	// AuthModePassword logs in with the credentials' email and password
+	// and sends the session's access token. For self-hosted SigNoz
+	// versions that don't support API keys.
+	AuthModePassword AuthMode = "Password"
+)
+
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// ActiveAPIKey is the API key of the credentials the provider last
+	// authenticated with. Secondary means apiKey was rejected and
+	// nextApiKey accepted: promote nextApiKey to apiKey. Unset unless the
+	// credentials have a nextApiKey.
+	// +optional
+	ActiveAPIKey APIKeySlot `json:"activeApiKey,omitempty"`
+
+	// ServerVersion is the version SigNoz last reported, e.g. v0.76.2.
+	// Payloads are built in the schema this version understands.
+	// +optional
+	ServerVersion string `json:"serverVersion,omitempty"`
+
+	// FeatureFlags are the feature flags SigNoz last reported, by name.
+	// +optional
+	FeatureFlags map[string]bool `json:"featureFlags,omitempty"`
+}
+
+// An APIKeySlot identifies one of the API keys of the credentials.
+// +kubebuilder:validation:Enum=Primary;Secondary
+type APIKeySlot string
+
+const (
+	// APIKeySlotPrimary is the credentials' apiKey.
+	APIKeySlotPrimary APIKeySlot = "Primary"
+
+	// APIKeySlotSecondary is the credentials' nextApiKey.
+	APIKeySlotSecondary APIKeySlot = "Secondary"
+)
+
+// ExternalChangePolicy determines what the provider does when a resource is
+// edited outside Kubernetes, for example in the SigNoz UI.
+// +kubebuilder:validation:Enum=Overwrite;Ignore
+type ExternalChangePolicy string
+
+const (
+	// ExternalChangePolicyOverwrite restores the desired state over any
+	// edit made in SigNoz. This is the default.
+	ExternalChangePolicyOverwrite ExternalChangePolicy = "Overwrite"
+
+	// ExternalChangePolicyIgnore leaves edits made in SigNoz in place until
+	// the resource's spec changes.
+	ExternalChangePolicyIgnore ExternalChangePolicy = "Ignore"
+)
+
+// DriftSummary records the most recent difference observed between a
+// managed resource's desired state and its state in SigNoz.
+type DriftSummary struct {
+	// DetectedAt is when the drift was observed.
+	DetectedAt metav1.Time `json:"detectedAt"`
+
+	// Fields are the differing fields, in payload order.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	Fields []DriftField `json:"fields,omitempty"`
+
+	// Omitted is the number of further differing fields not listed in
+	// Fields.
+	// +optional
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// DriftField is a single field that differs from its desired value.
+type DriftField struct {
+	// Path is the field's path in the SigNoz payload, e.g.
+	// condition.compositeQuery.queries[0].spec.stepInterval.
+	Path string `json:"path"`
+
+	// Desired is the value sent to SigNoz, truncated, with sensitive
+	// values redacted.
+	// +optional
+	Desired string `json:"desired,omitempty"`
+
+	// Observed is the value SigNoz returned, truncated, with sensitive
+	// values redacted.
+	// +optional
+	Observed string `json:"observed,omitempty"`
 }
 
 // +kubebuilder:object:root=true
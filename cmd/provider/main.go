@@ -18,6 +18,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -31,6 +33,8 @@ import (
 	"github.com/rossigee/provider-signoz/internal/breaker"
 	"github.com/rossigee/provider-signoz/internal/clients"
 	"github.com/rossigee/provider-signoz/internal/controller"
+	"github.com/rossigee/provider-signoz/internal/controller/channel"
+	"github.com/rossigee/provider-signoz/internal/controller/prometheusrule"
 	"github.com/rossigee/provider-signoz/internal/controller/providerconfig"
 	"github.com/rossigee/provider-signoz/internal/tracing"
 	"github.com/rossigee/provider-signoz/internal/version"
@@ -66,9 +70,27 @@ func main() {
 		authFailureThreshold = app.Flag("auth-failure-threshold", "Number of consecutive auth failures within the window that trip the breaker.").Default("5").Int()
 		authFailureCooldown  = app.Flag("auth-failure-cooldown", "Duration the breaker stays open after tripping before allowing a probe.").Default("5m").Duration()
 		probeConnTimeout     = app.Flag("probe-conn-timeout", "Per-attempt timeout for ProviderConfig credentials probe.").Default("10s").Duration()
+
+		enablePrometheusRuleImport    = app.Flag("enable-prometheusrule-import", "Import PrometheusRules labelled "+prometheusrule.LabelImport+"=true as Alerts. Requires the monitoring.coreos.com CRDs.").Default("false").Envar("ENABLE_PROMETHEUSRULE_IMPORT").Bool()
+		prometheusRuleChannelSelector = app.Flag("prometheusrule-channel-selector", "Label selector of the NotificationChannels imported PrometheusRule Alerts notify, e.g. team=platform.").Envar("PROMETHEUSRULE_CHANNEL_SELECTOR").String()
+
+		_ = app.Command("start", "Start the provider.").Default()
+
+		importAlertmanager               = app.Command("import-alertmanager", "Convert the receivers of an alertmanager.yml into NotificationChannel and Secret manifests.")
+		importAlertmanagerFile           = importAlertmanager.Arg("file", "Path to alertmanager.yml, or - to read standard input.").Required().String()
+		importAlertmanagerNamespace      = importAlertmanager.Flag("namespace", "Namespace of the generated manifests.").Default("default").String()
+		importAlertmanagerNamePrefix     = importAlertmanager.Flag("name-prefix", "Prefix for the names of the generated manifests.").String()
+		importAlertmanagerProviderConfig = importAlertmanager.Flag("provider-config", "ClusterProviderConfig the generated NotificationChannels use.").String()
 	)
 
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	if kingpin.MustParse(app.Parse(os.Args[1:])) == importAlertmanager.FullCommand() {
+		kingpin.FatalIfError(runImportAlertmanager(*importAlertmanagerFile, channel.AlertmanagerOptions{
+			Namespace:          *importAlertmanagerNamespace,
+			NamePrefix:         *importAlertmanagerNamePrefix,
+			ProviderConfigName: *importAlertmanagerProviderConfig,
+		}), "Cannot import Alertmanager receivers")
+		return
+	}
 
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-signoz"))
@@ -181,6 +203,13 @@ func main() {
 	}
 	kingpin.FatalIfError(controller.SetupWithPCConfig(mgr, o, pcCfg), "Cannot setup controllers")
 
+	if *enablePrometheusRuleImport {
+		selector, err := prometheusrule.ParseChannelSelector(*prometheusRuleChannelSelector)
+		kingpin.FatalIfError(err, "Cannot parse PrometheusRule channel selector")
+		kingpin.FatalIfError(prometheusrule.Setup(mgr, o, prometheusrule.Config{ChannelSelector: selector}), "Cannot setup PrometheusRule import")
+		log.Info("PrometheusRule import enabled", "channel-selector", *prometheusRuleChannelSelector)
+	}
+
 	kingpin.FatalIfError(mgr.AddHealthzCheck("healthz", healthz.Ping), "Cannot add health check")
 	kingpin.FatalIfError(mgr.AddReadyzCheck("readyz", healthz.Ping), "Cannot add ready check")
 
@@ -191,3 +220,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// runImportAlertmanager writes the manifests converted from an
+// alertmanager.yml to standard output, and what couldn't be converted to
+// standard error.
+func runImportAlertmanager(path string, o channel.AlertmanagerOptions) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filepath.Clean(path))
+	}
+	if err != nil {
+		return err
+	}
+	imported, err := channel.ConvertAlertmanagerConfig(data, o)
+	if err != nil {
+		return err
+	}
+	for _, w := range imported.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	return imported.WriteManifests(os.Stdout)
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// SigNoz error types. The v1 API reports them in the errorType field of the
+// error envelope, and newer APIs as the code of a structured error.
+const (
+	ErrorTypeNotFound      = "not_found"
+	ErrorTypeBadData       = "bad_data"
+	ErrorTypeInvalidInput  = "invalid_input"
+	ErrorTypeConflict      = "conflict"
+	ErrorTypeAlreadyExists = "already_exists"
+)
+
+// maxErrorMessage bounds the length of a message taken from a response body
+// that isn't a SigNoz error envelope.
+const maxErrorMessage = 512
+
+// An APIError is returned for every SigNoz API response with a status of
+// 400 or above. Use errors.As to inspect it, or the IsNotFound, IsConflict
+// and IsValidation helpers. It also satisfies errors.Is for the ErrAuth,
+// ErrTransient and ErrRateLimited sentinels matching its status.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+
+	// Method and Path identify the request. Query values in Path are
+	// redacted.
+	Method string
+	Path   string
+
+	// ErrorType is the SigNoz error type, e.g. "not_found" or "bad_data".
+	// Empty if the response wasn't a SigNoz error envelope.
+	ErrorType string
+
+	// Message is the SigNoz error message, or the start of the response
+	// body if it wasn't a SigNoz error envelope. Secrets are redacted.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.ErrorType != "" {
+		status += " (" + e.ErrorType + ")"
+	}
+	if e.Message == "" {
+		return fmt.Sprintf("signoz API error: %s %s: %s", e.Method, e.Path, status)
+	}
+	return fmt.Sprintf("signoz API error: %s %s: %s: %s", e.Method, e.Path, status, e.Message)
+}
+
+// Is reports the sentinel matching the error's status code, so callers can
+// classify it with errors.Is.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrAuth:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrTransient:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// errorEnvelope is the body of a SigNoz error response. The v1 API sets
+// Error to a message string and ErrorType alongside it; newer APIs set
+// Error to an object with a code and message.
+type errorEnvelope struct {
+	Status    string          `json:"status"`
+	Error     json.RawMessage `json:"error"`
+	ErrorType string          `json:"errorType"`
+}
+
+type structuredError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// newAPIError builds an APIError from an error response's status and body.
+func newAPIError(method, path string, statusCode int, body []byte) *APIError {
+	e := &APIError{StatusCode: statusCode, Method: method, Path: redactAPIPath(path)}
+
+	var env errorEnvelope
+	if json.Unmarshal(body, &env) == nil && (len(env.Error) > 0 || env.ErrorType != "") {
+		e.ErrorType = env.ErrorType
+		var msg string
+		var se structuredError
+		switch {
+		case json.Unmarshal(env.Error, &msg) == nil:
+			e.Message = RedactString(msg)
+		case json.Unmarshal(env.Error, &se) == nil:
+			e.Message = RedactString(se.Message)
+			if e.ErrorType == "" {
+				e.ErrorType = se.Code
+			}
+		}
+		return e
+	}
+
+	msg := RedactString(strings.TrimSpace(string(body)))
+	if len(msg) > maxErrorMessage {
+		msg = msg[:maxErrorMessage] + "..."
+	}
+	e.Message = msg
+	return e
+}
+
+// IsNotFound returns true if err is a SigNoz API error reporting that the
+// requested resource doesn't exist.
+func IsNotFound(err error) bool {
+	var e *APIError
+	return stderrors.As(err, &e) && (e.StatusCode == http.StatusNotFound || e.ErrorType == ErrorTypeNotFound)
+}
+
+// IsConflict returns true if err is a SigNoz API error reporting that the
+// request conflicts with an existing resource.
+func IsConflict(err error) bool {
+	var e *APIError
+	return stderrors.As(err, &e) && (e.StatusCode == http.StatusConflict ||
+		e.ErrorType == ErrorTypeConflict || e.ErrorType == ErrorTypeAlreadyExists)
+}
+
+// IsValidation returns true if err is a SigNoz API error rejecting the
+// request's content. Retrying the same request won't succeed.
+func IsValidation(err error) bool {
+	var e *APIError
+	return stderrors.As(err, &e) && (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity ||
+		e.ErrorType == ErrorTypeBadData || e.ErrorType == ErrorTypeInvalidInput)
+}
@@ -32,6 +32,20 @@ const (
 	ClassRateLimited
 )
 
+// String returns the class as logged.
+func (c BackoffClass) String() string {
+	switch c {
+	case ClassAuth:
+		return "auth"
+	case ClassTransient:
+		return "transient"
+	case ClassRateLimited:
+		return "rate-limited"
+	default:
+		return "none"
+	}
+}
+
 // BackoffPolicy decides which duration to recommend for a given error class.
 // ProviderConfig reconciler uses AuthRequeueMin/Max; managed controllers use
 // TransientInitial/Cap so a transient outage doesn't sit on a 5-minute
@@ -0,0 +1,233 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	versionPath      = "/api/v1/version"
+	featureFlagsPath = "/api/v1/featureFlags"
+)
+
+// The oldest SigNoz releases the payload shapes built by the controllers
+// are known to work with. Servers of an unknown version are assumed to be
+// recent enough.
+const (
+	// minRuleV5Version introduced the v5 rule envelope and its
+	// compositeQuery.queries list. Older servers take the v4 shape, with
+	// builderQueries and promQueries maps, which isn't built.
+	minRuleV5Version = "v0.85.0"
+
+	// minThresholdSchemaVersion introduced multi-level thresholds, sent
+	// with schemaVersion v2alpha1 and an evaluation block.
+	minThresholdSchemaVersion = "v0.90.0"
+
+	// minDashboardV2Version introduced the v2 dashboards API and its
+	// schemaVersion v6 payload.
+	minDashboardV2Version = "v0.85.0"
+)
+
+// Rule and dashboard schema versions.
+const (
+	RuleVersionV5            = "v5"
+	ThresholdSchemaV2Alpha1  = "v2alpha1"
+	DashboardSchemaVersionV6 = "v6"
+)
+
+const errUnsupportedServer = "unsupported SigNoz server"
+
+// Capabilities describes the SigNoz server a ProviderConfig points at, as
+// last detected by the ProviderConfig reconciler. Payload builders use it
+// to pick the schema the server understands, and fail clearly when it
+// doesn't support a feature at all.
+type Capabilities struct {
+	// Version is the server's version, e.g. v0.76.2. Empty if unknown.
+	Version string
+
+	// Features are the server's feature flags by name.
+	Features map[string]bool
+}
+
+// Feature returns true if the server reported the named feature flag as
+// active.
+func (c Capabilities) Feature(name string) bool {
+	return c.Features[name]
+}
+
+// AtLeast returns true if the server's version is min or later, or if the
+// version is unknown.
+func (c Capabilities) AtLeast(min string) bool {
+	v, ok := parseVersion(c.Version)
+	if !ok {
+		return true
+	}
+	m, _ := parseVersion(min)
+	for i := range v {
+		if v[i] != m[i] {
+			return v[i] > m[i]
+		}
+	}
+	return true
+}
+
+// require returns an error naming what needs a later server unless the
+// server's version is min or later.
+func (c Capabilities) require(min, what string) error {
+	if c.AtLeast(min) {
+		return nil
+	}
+	return errors.Errorf("%s: %s requires SigNoz %s or later, the server is %s", errUnsupportedServer, what, min, c.Version)
+}
+
+// RuleVersion returns the alert rule envelope version the server takes,
+// or an error if the server doesn't take v5 envelopes.
+func (c Capabilities) RuleVersion() (string, error) {
+	if err := c.require(minRuleV5Version, "an alert"); err != nil {
+		return "", err
+	}
+	return RuleVersionV5, nil
+}
+
+// ThresholdSchemaVersion returns the rule schemaVersion of alerts with
+// multi-level thresholds, or an error if the server doesn't support them.
+func (c Capabilities) ThresholdSchemaVersion() (string, error) {
+	if err := c.require(minThresholdSchemaVersion, "an alert with thresholds"); err != nil {
+		return "", err
+	}
+	return ThresholdSchemaV2Alpha1, nil
+}
+
+// DashboardSchemaVersion returns the schemaVersion of dashboards, or an
+// error if the server doesn't support the v2 dashboards API.
+func (c Capabilities) DashboardSchemaVersion() (string, error) {
+	if err := c.require(minDashboardV2Version, "a dashboard"); err != nil {
+		return "", err
+	}
+	return DashboardSchemaVersionV6, nil
+}
+
+// parseVersion parses a version such as v0.76.2 or 0.76.2-rc.1 into its
+// major, minor and patch numbers.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if s == "" || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// Capabilities returns the capabilities of the server the Client talks to,
+// as recorded in its ProviderConfig's status.
+func (c *Client) Capabilities() Capabilities {
+	return c.config.Capabilities
+}
+
+// versionResponse is the body of the version endpoint.
+type versionResponse struct {
+	Version string `json:"version"`
+}
+
+// featureFlag is an entry of the feature flags endpoint.
+type featureFlag struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// DetectCapabilities queries the server's version and feature flags. The
+// feature flags are best effort: servers that don't serve them, or don't
+// let the credentials read them, report none.
+func (c *Client) DetectCapabilities(ctx context.Context) (Capabilities, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, versionPath, nil)
+	if err != nil {
+		return Capabilities{}, errors.Wrap(err, "cannot get SigNoz version")
+	}
+	var v versionResponse
+	if err := parseResponse(resp, &v); err != nil {
+		return Capabilities{}, errors.Wrap(err, "cannot get SigNoz version")
+	}
+	caps := Capabilities{Version: v.Version}
+
+	resp, err = c.bestEffortRequest(ctx, http.MethodGet, featureFlagsPath)
+	if err != nil {
+		return caps, nil
+	}
+	if resp.StatusCode >= 400 {
+		_ = resp.Body.Close()
+		return caps, nil
+	}
+	var raw json.RawMessage
+	if err := parseResponse(resp, &raw); err != nil {
+		return caps, nil
+	}
+	// Older servers return the list, newer ones wrap it in a data envelope.
+	var flags []featureFlag
+	if json.Unmarshal(raw, &flags) != nil {
+		var env struct {
+			Data []featureFlag `json:"data"`
+		}
+		if json.Unmarshal(raw, &env) != nil {
+			return caps, nil
+		}
+		flags = env.Data
+	}
+	caps.Features = make(map[string]bool, len(flags))
+	for _, f := range flags {
+		caps.Features[f.Name] = f.Active
+	}
+	return caps, nil
+}
+
+// bestEffortRequest makes a request whose failure is expected on some
+// servers. Unlike doRequest its outcome isn't recorded on the auth breaker,
+// so a 403 to credentials that may not read the resource doesn't count as
+// an auth failure. Error responses are returned for the caller to handle.
+func (c *Client) bestEffortRequest(ctx context.Context, method, path string) (*http.Response, error) {
+	if err := c.config.credentialsError(); err != nil {
+		return nil, err
+	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := c.send(ctx, method, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		pauseEndpoint(c.config.BaseURL, parseRetryAfter(resp.Header.Get("Retry-After")))
+	}
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCapabilities_AtLeast(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		min     string
+		want    bool
+	}{
+		"Newer":       {version: "v0.92.1", min: "v0.90.0", want: true},
+		"Equal":       {version: "v0.90.0", min: "v0.90.0", want: true},
+		"Older":       {version: "v0.76.2", min: "v0.90.0", want: false},
+		"NoPrefix":    {version: "0.91.0", min: "v0.90.0", want: true},
+		"PreRelease":  {version: "v0.89.0-rc.1", min: "v0.90.0", want: false},
+		"NewerMajor":  {version: "v1.0", min: "v0.90.0", want: true},
+		"Unknown":     {version: "", min: "v0.90.0", want: true},
+		"Unparseable": {version: "latest", min: "v0.90.0", want: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := (Capabilities{Version: tc.version}).AtLeast(tc.min); got != tc.want {
+				t.Errorf("AtLeast(%q) on %q = %v, want %v", tc.min, tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCapabilities_Schemas(t *testing.T) {
+	old := Capabilities{Version: "v0.70.0"}
+	if _, err := old.RuleVersion(); err == nil || !strings.Contains(err.Error(), "an alert requires SigNoz v0.85.0 or later") {
+		t.Errorf("RuleVersion() on v0.70.0 error = %v", err)
+	}
+	_, err := old.ThresholdSchemaVersion()
+	if err == nil || !strings.Contains(err.Error(), "requires SigNoz v0.90.0 or later, the server is v0.70.0") {
+		t.Errorf("ThresholdSchemaVersion() on v0.70.0 error = %v", err)
+	}
+	if _, err := old.DashboardSchemaVersion(); err == nil {
+		t.Error("DashboardSchemaVersion() on v0.70.0 succeeded, want an error")
+	}
+
+	var unknown Capabilities
+	if got, err := unknown.RuleVersion(); err != nil || got != RuleVersionV5 {
+		t.Errorf("RuleVersion() of an unknown server = %q, %v", got, err)
+	}
+	if got, err := unknown.DashboardSchemaVersion(); err != nil || got != DashboardSchemaVersionV6 {
+		t.Errorf("DashboardSchemaVersion() of an unknown server = %q, %v", got, err)
+	}
+}
+
+func TestClient_DetectCapabilities(t *testing.T) {
+	flags := `[{"name":"ANOMALY_DETECTION","active":true},{"name":"SSO","active":false}]`
+	forbidden := false
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case versionPath:
+			_, _ = w.Write([]byte(`{"version":"v0.92.1","ee":"Y"}`))
+		case featureFlagsPath:
+			if forbidden {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(flags))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"})
+
+	caps, err := c.DetectCapabilities(context.Background())
+	if err != nil {
+		t.Fatalf("DetectCapabilities() error = %v", err)
+	}
+	if caps.Version != "v0.92.1" || !caps.Feature("ANOMALY_DETECTION") || caps.Feature("SSO") {
+		t.Errorf("DetectCapabilities() = %+v", caps)
+	}
+
+	// Newer servers wrap the flags in a data envelope.
+	flags = `{"status":"success","data":[{"name":"SSO","active":true}]}`
+	if caps, err = c.DetectCapabilities(context.Background()); err != nil || !caps.Feature("SSO") {
+		t.Errorf("DetectCapabilities() with wrapped flags = %+v, %v", caps, err)
+	}
+
+	// Feature flags are best effort.
+	flags = `not json`
+	if caps, err = c.DetectCapabilities(context.Background()); err != nil || caps.Version != "v0.92.1" || caps.Features != nil {
+		t.Errorf("DetectCapabilities() with bad flags = %+v, %v", caps, err)
+	}
+
+	// Credentials that may not read the flags aren't an auth failure.
+	rec := &fakeAuthBreaker{}
+	defer installBreaker(rec)()
+	forbidden = true
+	if caps, err = c.DetectCapabilities(context.Background()); err != nil || caps.Version != "v0.92.1" || caps.Features != nil {
+		t.Errorf("DetectCapabilities() with forbidden flags = %+v, %v", caps, err)
+	}
+	for _, authFailure := range rec.recordedAuth {
+		if authFailure {
+			t.Errorf("breaker recorded an auth failure: %v", rec.recordedAuth)
+		}
+	}
+}
@@ -39,7 +39,7 @@ func RecordUpstreamCondition(ctx context.Context, status *xpv1.ConditionedStatus
 			Type:    TypeUpstreamAuth,
 			Status:  corev1.ConditionFalse,
 			Reason:  ReasonUpstreamAuthRejected,
-			Message: err.Error(),
+			Message: RedactString(err.Error()),
 		})
 	case ClassNone:
 		if clearOnSuccess {
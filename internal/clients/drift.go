@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReasonDriftDetected is the event reason emitted when Observe finds the
+// resource in SigNoz differs from its desired state.
+const ReasonDriftDetected event.Reason = "DriftDetected"
+
+const (
+	// MaxDriftFields caps the fields kept in status.atProvider.lastDrift
+	// and listed in drift events.
+	MaxDriftFields = 10
+
+	// maxDriftValueLen caps each rendered value, so a drifted query or
+	// panel can't blow up an event or the status.
+	maxDriftValueLen = 120
+
+	absentValue = "<absent>"
+)
+
+// A FieldDiff is one field whose observed value differs from the desired
+// one. Values are rendered for humans: truncated, with sensitive fields
+// redacted.
+type FieldDiff struct {
+	Path     string
+	Desired  string
+	Observed string
+}
+
+// A Diff lists the fields that differ between desired and observed state,
+// in the order the comparator visited them. An empty Diff means up to date.
+type Diff []FieldDiff
+
+// Add records that the field at path differs.
+func (d *Diff) Add(path string, desired, observed interface{}) {
+	sensitive := isSensitiveField(path)
+	*d = append(*d, FieldDiff{
+		Path:     path,
+		Desired:  renderDriftValue(desired, sensitive),
+		Observed: renderDriftValue(observed, sensitive),
+	})
+}
+
+// Empty reports whether no differences were recorded.
+func (d Diff) Empty() bool {
+	return len(d) == 0
+}
+
+// String renders at most MaxDriftFields differences on one line, e.g.
+// `alert: "High CPU" -> "CPU"; severity: "critical" -> "warning"`.
+func (d Diff) String() string {
+	parts := make([]string, 0, MaxDriftFields+1)
+	for i, f := range d {
+		if i == MaxDriftFields {
+			parts = append(parts, fmt.Sprintf("and %d more", len(d)-MaxDriftFields))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s -> %s", f.Path, f.Desired, f.Observed))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Summary returns the bounded status representation of the diff.
+func (d Diff) Summary(now time.Time) *v1beta1.DriftSummary {
+	s := &v1beta1.DriftSummary{DetectedAt: metav1.NewTime(now)}
+	for i, f := range d {
+		if i == MaxDriftFields {
+			s.Omitted = len(d) - MaxDriftFields
+			break
+		}
+		s.Fields = append(s.Fields, v1beta1.DriftField{Path: f.Path, Desired: f.Desired, Observed: f.Observed})
+	}
+	return s
+}
+
+// RecordDrift emits a DriftDetected event for a non-empty diff and returns
+// the summary to store in status.atProvider.lastDrift. It returns nil when
+// the diff is empty, so callers keep the previous summary. A nil recorder
+// only skips the event.
+func RecordDrift(rec event.Recorder, mg resource.Managed, d Diff) *v1beta1.DriftSummary {
+	if d.Empty() {
+		return nil
+	}
+	if rec != nil {
+		rec.Event(mg, event.Normal(ReasonDriftDetected, "Resource differs from SigNoz: "+d.String()))
+	}
+	return d.Summary(time.Now())
+}
+
+// JoinPath appends a map key to a dotted field path.
+func JoinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// IndexPath appends a slice index to a field path.
+func IndexPath(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// StringSetsEqual returns true if a and b hold the same strings, in any
+// order and ignoring duplicates, as SigNoz may reorder and dedupe lists
+// such as channel and alert IDs.
+func StringSetsEqual(a, b []string) bool {
+	set := make(map[string]bool, len(a))
+	for _, s := range a {
+		set[s] = true
+	}
+	seen := make(map[string]bool, len(b))
+	for _, s := range b {
+		if !set[s] {
+			return false
+		}
+		seen[s] = true
+	}
+	return len(seen) == len(set)
+}
+
+func renderDriftValue(v interface{}, sensitive bool) string {
+	if v == nil {
+		return absentValue
+	}
+	if sensitive {
+		return redactedValue
+	}
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = fmt.Sprintf("%q", RedactString(x))
+	case bool, int, int32, int64, uint, uint64, float32, float64:
+		s = fmt.Sprintf("%v", x)
+	default:
+		raw, err := json.Marshal(x)
+		if err != nil {
+			s = RedactString(fmt.Sprintf("%v", x))
+		} else {
+			s = RedactJSON(raw)
+		}
+	}
+	return truncate(s, maxDriftValueLen)
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n]) + "..."
+}
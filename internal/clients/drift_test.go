@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type recordedEvents []event.Event
+
+func (r *recordedEvents) Event(_ runtime.Object, e event.Event) { *r = append(*r, e) }
+
+func (r *recordedEvents) WithAnnotations(...string) event.Recorder { return r }
+
+func TestDiffAdd_RendersValues(t *testing.T) {
+	var d Diff
+	d.Add("alert", "High CPU", "CPU")
+	d.Add("condition.target", 80.0, 90)
+	d.Add("disabled", false, nil)
+	d.Add("labels", map[string]string{"team": "web"}, nil)
+
+	want := []FieldDiff{
+		{Path: "alert", Desired: `"High CPU"`, Observed: `"CPU"`},
+		{Path: "condition.target", Desired: "80", Observed: "90"},
+		{Path: "disabled", Desired: "false", Observed: absentValue},
+		{Path: "labels", Desired: `{"team":"web"}`, Observed: absentValue},
+	}
+	for i, w := range want {
+		if d[i] != w {
+			t.Errorf("d[%d] = %+v, want %+v", i, d[i], w)
+		}
+	}
+}
+
+func TestDiffAdd_RedactsSensitiveFields(t *testing.T) {
+	for _, path := range []string{
+		"slack_configs[0].api_url",
+		"webhook_configs[0].url",
+		"pagerduty_configs[0].routing_key",
+		"opsgenie_configs[0].apiKey",
+		"http_config.authorization",
+	} {
+		var d Diff
+		d.Add(path, "https://hooks.slack.com/services/T0/B0/secret", "other")
+		if d[0].Desired != redactedValue || d[0].Observed != redactedValue {
+			t.Errorf("%s: got %+v, want both values redacted", path, d[0])
+		}
+	}
+
+	// An absent value is still reported as absent: it leaks nothing and
+	// tells the reader the field was added or removed.
+	var d Diff
+	d.Add("webhook_configs[0].url", "https://example.com/hook", nil)
+	if d[0].Observed != absentValue {
+		t.Errorf("Observed = %q, want %q", d[0].Observed, absentValue)
+	}
+}
+
+func TestDiffAdd_TruncatesLongValues(t *testing.T) {
+	var d Diff
+	d.Add("condition.compositeQuery.queries[0].spec.query", strings.Repeat("x", 500), "y")
+	if got := d[0].Desired; len(got) != maxDriftValueLen+len("...") || !strings.HasSuffix(got, "...") {
+		t.Errorf("Desired has length %d, want it truncated to %d runes plus an ellipsis", len(got), maxDriftValueLen)
+	}
+}
+
+func TestDiff_BoundedOutput(t *testing.T) {
+	var d Diff
+	for i := 0; i < MaxDriftFields+3; i++ {
+		d.Add(fmt.Sprintf("labels.k%d", i), "a", "b")
+	}
+
+	if s := d.String(); !strings.HasSuffix(s, "; and 3 more") {
+		t.Errorf("String() = %q, want it to end with the omitted count", s)
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	summary := d.Summary(now)
+	if len(summary.Fields) != MaxDriftFields || summary.Omitted != 3 {
+		t.Errorf("Summary() kept %d fields and omitted %d, want %d and 3", len(summary.Fields), summary.Omitted, MaxDriftFields)
+	}
+	if !summary.DetectedAt.Time.Equal(now) {
+		t.Errorf("DetectedAt = %v, want %v", summary.DetectedAt, now)
+	}
+}
+
+func TestRecordDrift(t *testing.T) {
+	var rec recordedEvents
+	mg := &fake.Managed{}
+
+	if s := RecordDrift(&rec, mg, nil); s != nil || len(rec) != 0 {
+		t.Errorf("RecordDrift(empty) = %v with %d events, want nil and no event", s, len(rec))
+	}
+
+	var d Diff
+	d.Add("severity", "critical", "warning")
+	s := RecordDrift(&rec, mg, d)
+	if s == nil || len(s.Fields) != 1 || s.Fields[0].Path != "severity" {
+		t.Fatalf("RecordDrift() = %+v, want a summary of the severity drift", s)
+	}
+	if len(rec) != 1 || rec[0].Reason != ReasonDriftDetected || rec[0].Type != event.TypeNormal {
+		t.Fatalf("events = %+v, want one Normal %s event", rec, ReasonDriftDetected)
+	}
+	if want := `severity: "critical" -> "warning"`; !strings.Contains(rec[0].Message, want) {
+		t.Errorf("Message = %q, want it to contain %q", rec[0].Message, want)
+	}
+
+	if s := RecordDrift(nil, mg, d); s == nil {
+		t.Error("RecordDrift(nil recorder) = nil, want the summary regardless")
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// TypeDriftLoopDetected is set True on a managed resource whose updates
+// don't converge: SigNoz keeps returning something the comparator reads as
+// drift however often the same desired state is sent. While it is True the
+// controller stops issuing updates for that desired state.
+const TypeDriftLoopDetected xpv1.ConditionType = "DriftLoopDetected"
+
+const (
+	ReasonUpdatesNotConverging = "UpdatesNotConverging"
+	ReasonUpdatesResumed       = "UpdatesResumed"
+)
+
+const (
+	// AnnotationLastAppliedHash holds the hash of the spec.forProvider the
+	// last successful update was made from.
+	AnnotationLastAppliedHash = "signoz.m.crossplane.io/last-applied-hash"
+
+	// AnnotationDriftLoopCount counts the consecutive updates made from
+	// that spec without Observe seeing the resource converge.
+	AnnotationDriftLoopCount = "signoz.m.crossplane.io/drift-loop-count"
+
+	// DriftLoopThreshold is the number of consecutive non-converging
+	// updates after which updates are held.
+	DriftLoopThreshold = 3
+)
+
+const errPatchAnnotations = "cannot patch annotations"
+
+// SpecHash returns a stable hash of a resource's desired state, normally its
+// spec.forProvider. Any change to the spec changes the hash.
+func SpecHash(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		// Parameters are plain API types; this can't fail in practice.
+		raw = []byte(fmt.Sprintf("%#v", v))
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+// driftLoopCount returns the number of non-converging updates recorded for
+// the spec with the supplied hash.
+func driftLoopCount(mg resource.Managed, hash string) int {
+	a := mg.GetAnnotations()
+	if a[AnnotationLastAppliedHash] != hash {
+		return 0
+	}
+	n, _ := strconv.Atoi(a[AnnotationDriftLoopCount])
+	return n
+}
+
+// ObserveDriftLoop is called from Observe with the diff between desired and
+// observed state, and returns true when updates should be held because the
+// same spec has already been applied DriftLoopThreshold times without
+// converging. It sets DriftLoopDetected with the persisting diff while
+// updates are held and clears it once the resource converges or the spec
+// changes. A converged resource has its loop count reset, so unrelated
+// drift later on starts counting from zero.
+func ObserveDriftLoop(ctx context.Context, kube client.Client, mg resource.Managed, hash string, d Diff) (bool, error) {
+	if d.Empty() {
+		clearDriftLoopCondition(mg, "Resource matches the desired state")
+		if _, ok := mg.GetAnnotations()[AnnotationDriftLoopCount]; !ok {
+			return false, nil
+		}
+		return false, patchAnnotations(ctx, kube, mg, func(a map[string]string) {
+			delete(a, AnnotationDriftLoopCount)
+		})
+	}
+
+	n := driftLoopCount(mg, hash)
+	if n < DriftLoopThreshold {
+		clearDriftLoopCondition(mg, "Desired state changed; updates resumed")
+		return false, nil
+	}
+
+	if mg.GetCondition(TypeDriftLoopDetected).Status != corev1.ConditionTrue {
+		log.FromContext(ctx).Info("Holding updates: resource still differs after repeated updates", "updates", n, "diff", d.String())
+	}
+	mg.SetConditions(xpv1.Condition{
+		Type:   TypeDriftLoopDetected,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonUpdatesNotConverging,
+		Message: fmt.Sprintf("Still differs from SigNoz after %d consecutive updates; updates are held until the spec changes: %s",
+			n, d.String()),
+	})
+	return true, nil
+}
+
+// RecordApplied is called after a successful update. It records the hash
+// of the spec the update was made from and counts consecutive updates of
+// the same spec.
+func RecordApplied(ctx context.Context, kube client.Client, mg resource.Managed, hash string) error {
+	n := driftLoopCount(mg, hash) + 1
+	return patchAnnotations(ctx, kube, mg, func(a map[string]string) {
+		a[AnnotationLastAppliedHash] = hash
+		a[AnnotationDriftLoopCount] = strconv.Itoa(n)
+	})
+}
+
+func clearDriftLoopCondition(mg resource.Managed, msg string) {
+	if mg.GetCondition(TypeDriftLoopDetected).Status != corev1.ConditionTrue {
+		return
+	}
+	mg.SetConditions(xpv1.Condition{
+		Type:    TypeDriftLoopDetected,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReasonUpdatesResumed,
+		Message: msg,
+	})
+}
+
+// patchAnnotations persists an annotation change. The managed reconciler
+// only writes status after Observe and Update, so the patch is made here.
+// It is applied to a copy so the status the caller has set so far isn't
+// replaced by the API server's view; only the annotations and the new
+// resourceVersion are carried back.
+func patchAnnotations(ctx context.Context, kube client.Client, mg resource.Managed, mutate func(map[string]string)) error {
+	obj, ok := mg.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.New(errPatchAnnotations)
+	}
+	base, _ := obj.DeepCopyObject().(client.Object)
+
+	a := obj.GetAnnotations()
+	if a == nil {
+		a = map[string]string{}
+	}
+	mutate(a)
+	obj.SetAnnotations(a)
+
+	if err := kube.Patch(ctx, obj, client.MergeFrom(base)); err != nil {
+		return errors.Wrap(err, errPatchAnnotations)
+	}
+	mg.SetAnnotations(obj.GetAnnotations())
+	mg.SetResourceVersion(obj.GetResourceVersion())
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDriftLoopAlert(t *testing.T) (client.Client, *alertv1beta1.Alert) {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := alertv1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add alert types to scheme: %v", err)
+	}
+	cr := &alertv1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "cpu", Namespace: "monitoring"}}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(cr).Build()
+	if err := kube.Get(context.Background(), client.ObjectKeyFromObject(cr), cr); err != nil {
+		t.Fatalf("cannot get alert: %v", err)
+	}
+	return kube, cr
+}
+
+func TestDriftLoop(t *testing.T) {
+	ctx := context.Background()
+	kube, cr := newDriftLoopAlert(t)
+
+	var drift Diff
+	drift.Add("condition.target", 80, "80")
+	hash := SpecHash(cr.Spec.ForProvider)
+
+	// Status set during this reconcile must survive the annotation patch.
+	cr.Status.AtProvider.ID = "rule-1"
+
+	for i := 1; i <= DriftLoopThreshold; i++ {
+		held, err := ObserveDriftLoop(ctx, kube, cr, hash, drift)
+		if err != nil || held {
+			t.Fatalf("cycle %d: ObserveDriftLoop() = %v, %v, want updates allowed", i, held, err)
+		}
+		if err := RecordApplied(ctx, kube, cr, hash); err != nil {
+			t.Fatalf("cycle %d: RecordApplied() = %v", i, err)
+		}
+	}
+	if cr.Status.AtProvider.ID != "rule-1" {
+		t.Errorf("status was overwritten by the annotation patch")
+	}
+
+	stored := &alertv1beta1.Alert{}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(cr), stored); err != nil {
+		t.Fatalf("cannot get alert: %v", err)
+	}
+	if got := stored.GetAnnotations()[AnnotationDriftLoopCount]; got != "3" {
+		t.Errorf("persisted %s = %q, want 3", AnnotationDriftLoopCount, got)
+	}
+
+	held, err := ObserveDriftLoop(ctx, kube, cr, hash, drift)
+	if err != nil || !held {
+		t.Fatalf("ObserveDriftLoop() = %v, %v, want updates held past the threshold", held, err)
+	}
+	c := cr.GetCondition(TypeDriftLoopDetected)
+	if c.Status != corev1.ConditionTrue || !strings.Contains(c.Message, "condition.target: 80 -> \"80\"") {
+		t.Errorf("DriftLoopDetected = %s %q, want True with the persisting diff", c.Status, c.Message)
+	}
+
+	// A spec change resumes updates.
+	held, err = ObserveDriftLoop(ctx, kube, cr, SpecHash(map[string]string{"changed": "spec"}), drift)
+	if err != nil || held {
+		t.Fatalf("ObserveDriftLoop() = %v, %v, want updates resumed after a spec change", held, err)
+	}
+	if c := cr.GetCondition(TypeDriftLoopDetected); c.Status != corev1.ConditionFalse || c.Reason != ReasonUpdatesResumed {
+		t.Errorf("DriftLoopDetected = %s/%s, want False/%s", c.Status, c.Reason, ReasonUpdatesResumed)
+	}
+
+	// Converging resets the count, so later drift starts from zero.
+	if _, err := ObserveDriftLoop(ctx, kube, cr, hash, nil); err != nil {
+		t.Fatalf("ObserveDriftLoop(converged) = %v", err)
+	}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(cr), stored); err != nil {
+		t.Fatalf("cannot get alert: %v", err)
+	}
+	if _, ok := stored.GetAnnotations()[AnnotationDriftLoopCount]; ok {
+		t.Errorf("expected %s to be removed once the resource converged", AnnotationDriftLoopCount)
+	}
+	if stored.GetAnnotations()[AnnotationLastAppliedHash] != hash {
+		t.Errorf("expected %s to be kept", AnnotationLastAppliedHash)
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TypeExternallyModified is set True on a managed resource that was edited
+// in SigNoz (typically in the UI) since the provider last applied it.
+const TypeExternallyModified xpv1.ConditionType = "ExternallyModified"
+
+const (
+	ReasonModifiedInSigNoz    = "ModifiedInSigNoz"
+	ReasonExternalEditIgnored = "ExternalEditIgnored"
+	ReasonMatchesDesiredState = "MatchesDesiredState"
+)
+
+const (
+	// AnnotationLastAppliedPayloadHash holds the hash of the last payload
+	// SigNoz was observed to match, whether the provider sent it or
+	// adopted an existing object.
+	AnnotationLastAppliedPayloadHash = "signoz.m.crossplane.io/last-applied-payload-hash"
+
+	// AnnotationLastObservedHash holds the hash of the SigNoz object as
+	// observed matching that payload.
+	AnnotationLastObservedHash = "signoz.m.crossplane.io/last-observed-hash"
+)
+
+// volatileFieldNames are top-level fields SigNoz changes on its own (ids,
+// timestamps, firing state, maintenance status), left out of StateHash.
+var volatileFieldNames = map[string]bool{
+	"id": true, "createdAt": true, "created_at": true, "updatedAt": true,
+	"updated_at": true, "createdBy": true, "updatedBy": true, "state": true,
+	"status": true,
+}
+
+// A ChangeOrigin says which side changed since the resource was last known
+// to match SigNoz.
+type ChangeOrigin int
+
+const (
+	// ChangeUnknown means there is no baseline to compare against, e.g.
+	// a new resource or one that predates the annotations.
+	ChangeUnknown ChangeOrigin = iota
+
+	// ChangeNone means neither the payload nor the SigNoz object changed,
+	// so the resource is still up to date without a full comparison.
+	ChangeNone
+
+	// ChangeSpec means the desired payload changed.
+	ChangeSpec
+
+	// ChangeExternal means the payload is unchanged but the SigNoz object
+	// isn't: someone edited it outside Kubernetes.
+	ChangeExternal
+)
+
+// StateHash hashes a payload or a SigNoz object for change detection.
+// Volatile top-level fields are dropped, and sensitive fields are dropped at
+// any depth so credentials never end up in an annotation, even hashed.
+func StateHash(v interface{}) string {
+	var generic interface{}
+	if raw, err := json.Marshal(v); err == nil && json.Unmarshal(raw, &generic) == nil {
+		if m, ok := generic.(map[string]interface{}); ok {
+			for k := range volatileFieldNames {
+				delete(m, k)
+			}
+		}
+		v = stripSensitive(generic)
+	}
+	return SpecHash(v)
+}
+
+// PayloadHash hashes a payload for change detection like StateHash, and
+// adds a digest of its sensitive values keyed by the resource's UID. An
+// edited or rotated credential thus changes the hash, so it is pushed to
+// SigNoz, without the annotation revealing it. Payloads without sensitive
+// values hash as StateHash does.
+func PayloadHash(mg resource.Managed, payload interface{}) string {
+	h := StateHash(payload)
+	var generic interface{}
+	if raw, err := json.Marshal(payload); err != nil || json.Unmarshal(raw, &generic) != nil {
+		return h
+	}
+	var values []string
+	collectSensitive(generic, "", &values)
+	if len(values) == 0 {
+		return h
+	}
+	sort.Strings(values)
+	mac := hmac.New(sha256.New, []byte(mg.GetUID()))
+	for _, v := range values {
+		mac.Write([]byte(v))
+		mac.Write([]byte{0})
+	}
+	return SpecHash([]string{h, hex.EncodeToString(mac.Sum(nil))})
+}
+
+// collectSensitive appends the path and value of each non-empty sensitive
+// field of v to values.
+func collectSensitive(v interface{}, path string, values *[]string) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			p := JoinPath(path, k)
+			if isSensitiveField(k) {
+				if e != nil && e != "" {
+					*values = append(*values, fmt.Sprintf("%s=%v", p, e))
+				}
+				continue
+			}
+			collectSensitive(e, p, values)
+		}
+	case []interface{}:
+		for i := range x {
+			collectSensitive(x[i], IndexPath(path, i), values)
+		}
+	}
+}
+
+func stripSensitive(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			if isSensitiveField(k) {
+				delete(x, k)
+				continue
+			}
+			x[k] = stripSensitive(e)
+		}
+	case []interface{}:
+		for i := range x {
+			x[i] = stripSensitive(x[i])
+		}
+	}
+	return v
+}
+
+// ClassifyChange compares the current payload and SigNoz object hashes
+// against the baseline recorded by RecordInSync.
+func ClassifyChange(mg resource.Managed, payloadHash, observedHash string) ChangeOrigin {
+	a := mg.GetAnnotations()
+	applied, observed := a[AnnotationLastAppliedPayloadHash], a[AnnotationLastObservedHash]
+	switch {
+	case applied == "":
+		return ChangeUnknown
+	case applied != payloadHash:
+		return ChangeSpec
+	case observed == "":
+		return ChangeUnknown
+	case observed != observedHash:
+		return ChangeExternal
+	default:
+		return ChangeNone
+	}
+}
+
+// ObserveExternalChange reports an edit made in SigNoz through the
+// ExternallyModified condition and returns true when the resource's policy
+// says to leave it alone. It is called from Observe with the full diff;
+// edits to fields the provider doesn't manage (an empty diff) are not
+// reported.
+func ObserveExternalChange(mg resource.Managed, policy v1beta1.ExternalChangePolicy, origin ChangeOrigin, d Diff) bool {
+	if origin != ChangeExternal || d.Empty() {
+		if mg.GetCondition(TypeExternallyModified).Status == corev1.ConditionTrue {
+			mg.SetConditions(xpv1.Condition{
+				Type:    TypeExternallyModified,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonMatchesDesiredState,
+				Message: "Resource matches the desired state or its spec changed",
+			})
+		}
+		return false
+	}
+
+	if policy == v1beta1.ExternalChangePolicyIgnore {
+		mg.SetConditions(xpv1.Condition{
+			Type:    TypeExternallyModified,
+			Status:  corev1.ConditionTrue,
+			Reason:  ReasonExternalEditIgnored,
+			Message: "Edited in SigNoz; left in place by externalChangePolicy Ignore: " + d.String(),
+		})
+		return true
+	}
+	mg.SetConditions(xpv1.Condition{
+		Type:    TypeExternallyModified,
+		Status:  corev1.ConditionTrue,
+		Reason:  ReasonModifiedInSigNoz,
+		Message: "Edited in SigNoz; restoring the desired state: " + d.String(),
+	})
+	return false
+}
+
+// RecordInSync records the baseline ClassifyChange compares against. It is
+// called from Observe when the resource matches its desired state, and only
+// patches when the baseline changed.
+func RecordInSync(ctx context.Context, kube client.Client, mg resource.Managed, payloadHash, observedHash string) error {
+	a := mg.GetAnnotations()
+	if a[AnnotationLastAppliedPayloadHash] == payloadHash && a[AnnotationLastObservedHash] == observedHash {
+		return nil
+	}
+	return patchAnnotations(ctx, kube, mg, func(a map[string]string) {
+		a[AnnotationLastAppliedPayloadHash] = payloadHash
+		a[AnnotationLastObservedHash] = observedHash
+	})
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStateHash(t *testing.T) {
+	rule := &RuleData{AlertName: "CPU", State: "inactive", UpdatedAt: "2024-05-01T12:00:00Z"}
+	firing := *rule
+	firing.State = "firing"
+	firing.UpdatedAt = "2024-05-01T12:05:00Z"
+	if StateHash(rule) != StateHash(&firing) {
+		t.Error("expected state and timestamps to be left out of the hash")
+	}
+
+	renamed := *rule
+	renamed.AlertName = "CPU usage"
+	if StateHash(rule) == StateHash(&renamed) {
+		t.Error("expected a renamed rule to hash differently")
+	}
+
+	slack := map[string]interface{}{"slack_configs": []interface{}{map[string]interface{}{"channel": "#ops", "api_url": "https://hooks.slack.com/a"}}}
+	rotated := map[string]interface{}{"slack_configs": []interface{}{map[string]interface{}{"channel": "#ops", "api_url": "https://hooks.slack.com/b"}}}
+	if StateHash(slack) != StateHash(rotated) {
+		t.Error("expected sensitive fields to be left out of the hash")
+	}
+}
+
+func TestPayloadHash(t *testing.T) {
+	_, cr := newDriftLoopAlert(t)
+	rule := &RuleData{AlertName: "CPU"}
+	if PayloadHash(cr, rule) != StateHash(rule) {
+		t.Error("expected a payload without sensitive values to hash as StateHash")
+	}
+
+	slack := map[string]interface{}{"slack_configs": []interface{}{map[string]interface{}{"channel": "#ops", "api_url": "https://hooks.slack.com/a"}}}
+	rotated := map[string]interface{}{"slack_configs": []interface{}{map[string]interface{}{"channel": "#ops", "api_url": "https://hooks.slack.com/b"}}}
+	h := PayloadHash(cr, slack)
+	if h == PayloadHash(cr, rotated) {
+		t.Error("expected a rotated credential to change the payload hash")
+	}
+	if h != PayloadHash(cr, slack) {
+		t.Error("expected the payload hash to be stable")
+	}
+	if strings.Contains(h, "hooks.slack.com") {
+		t.Error("payload hash reveals the credential")
+	}
+}
+
+func TestClassifyChange(t *testing.T) {
+	for name, tc := range map[string]struct {
+		annotations map[string]string
+		want        ChangeOrigin
+	}{
+		"NoBaseline": {want: ChangeUnknown},
+		"Unchanged": {
+			annotations: map[string]string{AnnotationLastAppliedPayloadHash: "p", AnnotationLastObservedHash: "o"},
+			want:        ChangeNone,
+		},
+		"SpecChanged": {
+			annotations: map[string]string{AnnotationLastAppliedPayloadHash: "old", AnnotationLastObservedHash: "o"},
+			want:        ChangeSpec,
+		},
+		"EditedInSigNoz": {
+			annotations: map[string]string{AnnotationLastAppliedPayloadHash: "p", AnnotationLastObservedHash: "old"},
+			want:        ChangeExternal,
+		},
+		"NoObservedBaseline": {
+			annotations: map[string]string{AnnotationLastAppliedPayloadHash: "p"},
+			want:        ChangeUnknown,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, cr := newDriftLoopAlert(t)
+			cr.SetAnnotations(tc.annotations)
+			if got := ClassifyChange(cr, "p", "o"); got != tc.want {
+				t.Errorf("ClassifyChange() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDriftCheck(t *testing.T) {
+	ctx := context.Background()
+	kube, cr := newDriftLoopAlert(t)
+
+	desired := &RuleData{AlertName: "CPU"}
+	observed := &RuleData{AlertName: "CPU", State: "inactive"}
+	diffs := 0
+	check := DriftCheck{
+		SpecHash: "spec",
+		Payload:  desired,
+		Observed: observed,
+		Diff: func() Diff {
+			diffs++
+			var d Diff
+			if desired.AlertName != observed.AlertName {
+				d.Add("alert", desired.AlertName, observed.AlertName)
+			}
+			return d
+		},
+	}
+
+	// In sync: the baseline is recorded.
+	if ok, _, err := check.UpToDate(ctx, kube, nil, cr); err != nil || !ok {
+		t.Fatalf("UpToDate() = %v, %v, want true", ok, err)
+	}
+	if cr.GetAnnotations()[AnnotationLastObservedHash] == "" {
+		t.Fatal("expected the observed baseline to be recorded")
+	}
+
+	// Nothing changed on either side: no full comparison.
+	observed.State = "firing"
+	if ok, _, err := check.UpToDate(ctx, kube, nil, cr); err != nil || !ok || diffs != 1 {
+		t.Fatalf("UpToDate() = %v, %v after %d diffs, want true without another diff", ok, err, diffs)
+	}
+
+	// Edited in the SigNoz UI, default policy: overwrite.
+	observed.AlertName = "CPU (edited)"
+	ok, drift, err := check.UpToDate(ctx, kube, nil, cr)
+	if err != nil || ok || drift == nil {
+		t.Fatalf("UpToDate() = %v, %v, %v, want drift to be reported and applied", ok, drift, err)
+	}
+	if c := cr.GetCondition(TypeExternallyModified); c.Status != corev1.ConditionTrue || c.Reason != ReasonModifiedInSigNoz {
+		t.Errorf("ExternallyModified = %s/%s, want True/%s", c.Status, c.Reason, ReasonModifiedInSigNoz)
+	}
+
+	// Same edit under the Ignore policy: left alone.
+	check.Policy = v1beta1.ExternalChangePolicyIgnore
+	if ok, _, err := check.UpToDate(ctx, kube, nil, cr); err != nil || !ok {
+		t.Fatalf("UpToDate() = %v, %v, want the edit left in place", ok, err)
+	}
+	if c := cr.GetCondition(TypeExternallyModified); c.Reason != ReasonExternalEditIgnored {
+		t.Errorf("ExternallyModified reason = %s, want %s", c.Reason, ReasonExternalEditIgnored)
+	}
+
+	// A spec change is applied regardless of the policy.
+	desired.AlertName = "CPU high"
+	if ok, _, err := check.UpToDate(ctx, kube, nil, cr); err != nil || ok {
+		t.Fatalf("UpToDate() = %v, %v, want the spec change applied", ok, err)
+	}
+	if c := cr.GetCondition(TypeExternallyModified); c.Status != corev1.ConditionFalse {
+		t.Errorf("ExternallyModified = %s, want False once the spec changed", c.Status)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "time"
+
+// PlannedMaintenanceDiff compares the desired downtime schedule with the
+// one observed in SigNoz.
+func PlannedMaintenanceDiff(desired, observed *PlannedMaintenanceData) Diff {
+	var d Diff
+	if desired.Name != observed.Name {
+		d.Add("name", desired.Name, observed.Name)
+	}
+	if desired.Description != observed.Description {
+		d.Add("description", desired.Description, observed.Description)
+	}
+	if !StringSetsEqual(desired.AlertIDs, observed.AlertIDs) {
+		d.Add("alertIds", desired.AlertIDs, observed.AlertIDs)
+	}
+
+	want, got := desired.Schedule, observed.Schedule
+	if got == nil {
+		got = &PlannedMaintenanceSchedule{}
+	}
+	if want.Timezone != got.Timezone {
+		d.Add("schedule.timezone", want.Timezone, got.Timezone)
+	}
+	if !timesEqual(want.StartTime, got.StartTime) {
+		d.Add("schedule.startTime", want.StartTime, got.StartTime)
+	}
+	if !timesEqual(want.EndTime, got.EndTime) {
+		d.Add("schedule.endTime", want.EndTime, got.EndTime)
+	}
+
+	switch {
+	case want.Recurrence == nil && got.Recurrence != nil:
+		d.Add("schedule.recurrence", nil, got.Recurrence)
+	case want.Recurrence != nil && got.Recurrence == nil:
+		d.Add("schedule.recurrence", want.Recurrence, nil)
+	case want.Recurrence != nil:
+		recurrenceDiff(&d, want.Recurrence, got.Recurrence)
+	}
+	return d
+}
+
+func recurrenceDiff(d *Diff, want, got *PlannedMaintenanceRecurrence) {
+	if !timesEqual(want.StartTime, got.StartTime) {
+		d.Add("schedule.recurrence.startTime", want.StartTime, got.StartTime)
+	}
+	if !timesEqual(want.EndTime, got.EndTime) {
+		d.Add("schedule.recurrence.endTime", want.EndTime, got.EndTime)
+	}
+	if !durationsEqual(want.Duration, got.Duration) {
+		d.Add("schedule.recurrence.duration", want.Duration, got.Duration)
+	}
+	if want.RepeatType != got.RepeatType {
+		d.Add("schedule.recurrence.repeatType", want.RepeatType, got.RepeatType)
+	}
+	if !StringSetsEqual(want.RepeatOn, got.RepeatOn) {
+		d.Add("schedule.recurrence.repeatOn", want.RepeatOn, got.RepeatOn)
+	}
+}
+
+// timesEqual compares two RFC 3339 times as instants, so the offset SigNoz
+// renders them with doesn't matter. SigNoz reports unset times as the zero
+// time.
+func timesEqual(a, b string) bool {
+	ta, errA := parseAPITime(a)
+	tb, errB := parseAPITime(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ta.Equal(tb)
+}
+
+func parseAPITime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// durationsEqual compares two durations by value, so "2h" matches "2h0m0s".
+func durationsEqual(a, b string) bool {
+	da, errA := time.ParseDuration(a)
+	db, errB := time.ParseDuration(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da == db
+}
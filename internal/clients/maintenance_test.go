@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import "testing"
+
+func TestTimesEqual(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"2025-07-01T22:00:00+01:00", "2025-07-01T21:00:00Z", true},
+		{"2025-07-01T21:00:00Z", "2025-07-01T21:00:00.000Z", true},
+		{"", "0001-01-01T00:00:00Z", true},
+		{"2025-07-01T21:00:00Z", "2025-07-01T22:00:00Z", false},
+		{"", "2025-07-01T21:00:00Z", false},
+	}
+	for _, tc := range cases {
+		if got := timesEqual(tc.a, tc.b); got != tc.want {
+			t.Errorf("timesEqual(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A DriftCheck decides whether a managed resource is up to date with the
+// object observed in SigNoz.
+type DriftCheck struct {
+	// SpecHash is the SpecHash of the resource's spec.forProvider.
+	SpecHash string
+
+	// Payload is what Create/Update would send, and Observed is what
+	// SigNoz returned. Both are only hashed; see PayloadHash and
+	// StateHash.
+	Payload  interface{}
+	Observed interface{}
+
+	// Policy is the resource's externalChangePolicy.
+	Policy v1beta1.ExternalChangePolicy
+
+	// Diff compares Payload against Observed field by field. It is not
+	// called when neither side changed since they last matched.
+	Diff func() Diff
+}
+
+// UpToDate runs the check. It emits a DriftDetected event for any drift
+// and returns the summary to store in status.atProvider.lastDrift (nil to
+// keep the previous one). A drifted resource is still reported up to date
+// when its updates are held, either because they don't converge
+// (DriftLoopDetected) or because it was edited in SigNoz and its policy is
+// to leave such edits alone (ExternallyModified).
+func (c DriftCheck) UpToDate(ctx context.Context, kube client.Client, rec event.Recorder, mg resource.Managed) (bool, *v1beta1.DriftSummary, error) {
+	payloadHash, observedHash := PayloadHash(mg, c.Payload), StateHash(c.Observed)
+	origin := ClassifyChange(mg, payloadHash, observedHash)
+	if origin == ChangeNone {
+		ObserveExternalChange(mg, c.Policy, origin, nil)
+		return true, nil, nil
+	}
+
+	d := c.Diff()
+	summary := RecordDrift(rec, mg, d)
+	looping, err := ObserveDriftLoop(ctx, kube, mg, c.SpecHash, d)
+	if err != nil {
+		return false, nil, err
+	}
+	ignored := ObserveExternalChange(mg, c.Policy, origin, d)
+	if d.Empty() {
+		if err := RecordInSync(ctx, kube, mg, payloadHash, observedHash); err != nil {
+			return false, nil, err
+		}
+	}
+	return d.Empty() || looping || ignored, summary, nil
+}
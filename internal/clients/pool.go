@@ -0,0 +1,198 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Transport tuning. Every Client shares a transport, so the idle pool is
+// sized for all ProviderConfigs of the process rather than one.
+const (
+	maxIdleConns          = 100
+	maxIdleConnsPerHost   = 10
+	idleConnTimeout       = 90 * time.Second
+	tlsHandshakeTimeout   = 10 * time.Second
+	expectContinueTimeout = 1 * time.Second
+	dialTimeout           = 30 * time.Second
+	dialKeepAlive         = 30 * time.Second
+)
+
+// transportKey identifies the connection settings that need a transport of
+// their own. Clients whose settings match share connections.
+type transportKey struct {
+	insecureSkipTLSVerify bool
+	tls                   string
+	proxy                 string
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[transportKey]*http.Transport{}
+)
+
+func transportKeyOf(cfg Config) transportKey {
+	return transportKey{
+		insecureSkipTLSVerify: cfg.InsecureSkipTLSVerify,
+		tls:                   cfg.TLS.fingerprint(),
+		proxy:                 cfg.Proxy.fingerprint(),
+	}
+}
+
+// sharedTransport returns the process-wide transport for cfg's connection
+// settings, creating it on first use. If cfg's TLS settings are invalid it
+// returns a RoundTripper that fails every request.
+func sharedTransport(cfg Config) http.RoundTripper {
+	k := transportKeyOf(cfg)
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	if t, ok := transports[k]; ok {
+		return t
+	}
+	tc, err := tlsConfig(cfg)
+	if err != nil {
+		return transportFailure{err: err}
+	}
+	t := &http.Transport{
+		Proxy: cfg.Proxy.proxyFunc(),
+		DialContext: (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: dialKeepAlive,
+		}).DialContext,
+		TLSClientConfig:       tc,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConnsPerHost,
+		IdleConnTimeout:       idleConnTimeout,
+		TLSHandshakeTimeout:   tlsHandshakeTimeout,
+		ExpectContinueTimeout: expectContinueTimeout,
+	}
+	transports[k] = t
+	return t
+}
+
+// releaseTransport drops the transport of k, closing its idle
+// connections. Clients still holding it keep working; the next Client with
+// these settings gets a new one.
+func releaseTransport(k transportKey) {
+	transportsMu.Lock()
+	t, ok := transports[k]
+	delete(transports, k)
+	transportsMu.Unlock()
+	if ok {
+		t.CloseIdleConnections()
+	}
+}
+
+// A ClientCache holds one Client per ProviderConfig. A cached Client is
+// reused for as long as the ProviderConfig's UID, resourceVersion and
+// credentials are unchanged, and replaced on the first lookup after any of
+// them changes, e.g. when the credentials Secret is rotated.
+type ClientCache struct {
+	mu      sync.Mutex
+	clients map[string]cachedClient
+}
+
+type cachedClient struct {
+	key       string
+	transport transportKey
+	client    *Client
+}
+
+// NewClientCache returns an empty ClientCache.
+func NewClientCache() *ClientCache {
+	return &ClientCache{clients: map[string]cachedClient{}}
+}
+
+// DefaultClientCache is the cache used by CachedClient.
+var DefaultClientCache = NewClientCache()
+
+// CachedClient returns the Client for cfg from DefaultClientCache. It has
+// the same signature as NewClient so controllers can use either.
+func CachedClient(cfg Config) *Client {
+	return DefaultClientCache.Get(cfg)
+}
+
+// Get returns the cached Client for cfg's ProviderConfig, creating it if
+// there is none or the cached one was built from an older revision or
+// other credentials. Configs that don't name a ProviderConfig aren't
+// cached.
+func (c *ClientCache) Get(cfg Config) *Client {
+	if cfg.ProviderConfig == "" {
+		return NewClient(cfg)
+	}
+	key := cfg.Revision + "/" + credentialsFingerprint(cfg)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, ok := c.clients[cfg.ProviderConfig]
+	if ok && old.key == key {
+		return old.client
+	}
+	cl := NewClient(cfg)
+	c.clients[cfg.ProviderConfig] = cachedClient{key: key, transport: transportKeyOf(cfg), client: cl}
+	if ok {
+		c.releaseUnused(old.transport)
+	}
+	return cl
+}
+
+// Invalidate drops the cached Client of the named ProviderConfig, e.g.
+// because it was deleted.
+func (c *ClientCache) Invalidate(providerConfig string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, ok := c.clients[providerConfig]
+	delete(c.clients, providerConfig)
+	if ok {
+		c.releaseUnused(old.transport)
+	}
+}
+
+// releaseUnused releases the transport of k unless a cached Client still
+// uses it, so transports of replaced connection settings don't pile up.
+// c.mu must be held.
+func (c *ClientCache) releaseUnused(k transportKey) {
+	for _, cc := range c.clients {
+		if cc.transport == k {
+			return
+		}
+	}
+	releaseTransport(k)
+}
+
+// Len returns the number of cached Clients.
+func (c *ClientCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.clients)
+}
+
+// credentialsFingerprint identifies the endpoint, credentials and
+// connection settings of cfg without revealing the apiKey.
+func credentialsFingerprint(cfg Config) string {
+	fp := breakerKey(cfg.BaseURL, cfg.credentialKey()) + "/" + cfg.TLS.fingerprint() + "/" +
+		cfg.Proxy.fingerprint() + "/" + headersFingerprint(cfg.Headers)
+	if cfg.InsecureSkipTLSVerify {
+		fp += "/insecure"
+	}
+	return fp
+}
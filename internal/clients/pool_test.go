@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestClientCache(t *testing.T) {
+	c := NewClientCache()
+	cfg := Config{
+		BaseURL:        "https://signoz.example.com",
+		APIKey:         "test-api-key-1234567890",
+		ProviderConfig: "/default",
+		Revision:       "uid-1/100",
+	}
+
+	first := c.Get(cfg)
+	if c.Get(cfg) != first {
+		t.Error("Get() built a new Client for an unchanged ProviderConfig")
+	}
+
+	cfg.Revision = "uid-1/101"
+	second := c.Get(cfg)
+	if second == first {
+		t.Error("Get() reused the Client after the ProviderConfig changed")
+	}
+
+	// A rotated Secret changes the credentials but not the ProviderConfig.
+	cfg.APIKey = "rotated-api-key-0987654321"
+	third := c.Get(cfg)
+	if third == second {
+		t.Error("Get() reused the Client after the credentials changed")
+	}
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want stale Clients replaced", c.Len())
+	}
+
+	// Replacing the only Client with some connection settings releases
+	// their transport.
+	cfg.InsecureSkipTLSVerify = true
+	insecure := c.Get(cfg).httpClient.Transport
+	cfg.InsecureSkipTLSVerify = false
+	c.Get(cfg)
+	k := transportKeyOf(Config{InsecureSkipTLSVerify: true})
+	transportsMu.Lock()
+	released := transports[k] != insecure
+	transportsMu.Unlock()
+	if !released {
+		t.Error("Get() kept the transport of replaced connection settings")
+	}
+	third = c.Get(cfg)
+
+	c.Invalidate("/default")
+	if c.Len() != 0 || c.Get(cfg) == third {
+		t.Error("Invalidate() didn't drop the cached Client")
+	}
+
+	// Configs not read from a ProviderConfig aren't cached.
+	if c.Get(Config{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey}) == c.Get(Config{BaseURL: cfg.BaseURL, APIKey: cfg.APIKey}) {
+		t.Error("Get() cached a Client without a ProviderConfig")
+	}
+}
+
+func TestSharedTransport(t *testing.T) {
+	a := NewClient(Config{BaseURL: "https://a.example.com", APIKey: "key-a-1234567890"})
+	b := NewClient(Config{BaseURL: "https://b.example.com", APIKey: "key-b-1234567890"})
+	insecure := NewClient(Config{BaseURL: "https://a.example.com", APIKey: "key-a-1234567890", InsecureSkipTLSVerify: true})
+
+	if a.httpClient.Transport != b.httpClient.Transport {
+		t.Error("Clients with the same connection settings don't share a transport")
+	}
+	if a.httpClient.Transport == insecure.httpClient.Transport {
+		t.Error("an insecure Client shares the verifying transport")
+	}
+
+	tr, ok := sharedTransport(Config{}).(*http.Transport)
+	if !ok {
+		t.Fatalf("sharedTransport() = %T, want *http.Transport", tr)
+	}
+	if !tr.ForceAttemptHTTP2 || tr.MaxIdleConnsPerHost != maxIdleConnsPerHost || tr.IdleConnTimeout != idleConnTimeout {
+		t.Errorf("transport isn't tuned: %+v", tr)
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+const (
+	errProxyURL            = "invalid proxy URL"
+	errGetProxyCredentials = "cannot get proxy credentials Secret"
+	errNoProxyCredentials  = "proxy credentials Secret has no username"
+)
+
+// ProxyOptions are the proxy settings of a Config.
+type ProxyOptions struct {
+	// URL of the proxy, including its credentials. When empty the proxy
+	// environment variables are honoured.
+	URL string
+
+	// NoProxy lists the hosts reached directly, in NO_PROXY format.
+	NoProxy string
+}
+
+// fingerprint identifies the options without revealing the proxy
+// credentials.
+func (o ProxyOptions) fingerprint() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(o.URL+"\x00"+o.NoProxy)))
+}
+
+// proxyFunc returns the Proxy function of a transport using the options.
+// Like the environment variables, a proxy URL never applies to requests to
+// localhost or loopback addresses.
+func (o ProxyOptions) proxyFunc() func(*http.Request) (*url.URL, error) {
+	if o.URL == "" {
+		return http.ProxyFromEnvironment
+	}
+	pf := (&httpproxy.Config{HTTPProxy: o.URL, HTTPSProxy: o.URL, NoProxy: o.NoProxy}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return pf(r.URL)
+	}
+}
+
+// getProxyOptions reads the proxy settings of a ProviderConfig, adding the
+// credentials from the referenced Secret to the proxy URL.
+func getProxyOptions(ctx context.Context, c client.Client, spec *v1beta1.ProxyConfig) (ProxyOptions, error) {
+	o := ProxyOptions{}
+	if spec == nil {
+		return o, nil
+	}
+	u, err := url.Parse(spec.URL)
+	if err != nil || u.Host == "" {
+		return o, errors.Errorf("%s: %q", errProxyURL, RedactURL(spec.URL))
+	}
+	if ref := spec.CredentialsSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return o, errors.Wrap(err, errGetProxyCredentials)
+		}
+		username := string(s.Data[corev1.BasicAuthUsernameKey])
+		if username == "" {
+			return o, errors.Errorf("%s: %s/%s", errNoProxyCredentials, ref.Namespace, ref.Name)
+		}
+		u.User = url.UserPassword(username, string(s.Data[corev1.BasicAuthPasswordKey]))
+	}
+	o.URL = u.String()
+	o.NoProxy = strings.Join(spec.NoProxy, ",")
+	return o, nil
+}
+
+// headersFingerprint identifies a set of extra headers.
+func headersFingerprint(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%s\x00", name, headers[name])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+func TestClient_ProxyAndHeaders(t *testing.T) {
+	var requests int
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		wantAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("egress:s3cret"))
+		switch {
+		case r.URL.Host != "signoz.test":
+			t.Errorf("proxied request for host %q, want signoz.test", r.URL.Host)
+		case r.Header.Get("Proxy-Authorization") != wantAuth:
+			t.Errorf("Proxy-Authorization = %q, want %q", r.Header.Get("Proxy-Authorization"), wantAuth)
+		case r.Header.Get("X-Gateway-Key") != "gw-123":
+			t.Errorf("X-Gateway-Key = %q, want the extra header", r.Header.Get("X-Gateway-Key"))
+		case r.Header.Get("SIGNOZ-API-KEY") != "test-api-key-1234567890":
+			t.Errorf("SIGNOZ-API-KEY = %q, want the configured key", r.Header.Get("SIGNOZ-API-KEY"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	kube := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy", Namespace: "crossplane-system"},
+		Data:       map[string][]byte{corev1.BasicAuthUsernameKey: []byte("egress"), corev1.BasicAuthPasswordKey: []byte("s3cret")},
+	}).Build()
+	po, err := getProxyOptions(context.Background(), kube, &v1beta1.ProxyConfig{
+		URL:                  proxy.URL,
+		CredentialsSecretRef: &xpv1.SecretReference{Name: "proxy", Namespace: "crossplane-system"},
+	})
+	if err != nil {
+		t.Fatalf("getProxyOptions() error = %v", err)
+	}
+
+	c := NewClient(Config{
+		BaseURL: "http://signoz.test",
+		APIKey:  "test-api-key-1234567890",
+		Proxy:   po,
+		Headers: map[string]string{"X-Gateway-Key": "gw-123", "SIGNOZ-API-KEY": "ignored"},
+	})
+	if err := c.ProbeCredentials(context.Background()); err != nil {
+		t.Errorf("ProbeCredentials() error = %v", err)
+	}
+	if err := c.DeleteChannel(context.Background(), "7"); err != nil {
+		t.Errorf("DeleteChannel() error = %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("proxy saw %d requests, want both the probe and the API call", requests)
+	}
+}
+
+func TestProxyOptions_NoProxy(t *testing.T) {
+	o := ProxyOptions{URL: "http://proxy.example.com:3128", NoProxy: "signoz.internal,.corp.example.com"}
+	pf := o.proxyFunc()
+	for target, proxied := range map[string]bool{
+		"https://api.signoz.cloud/api/v1/rules":         true,
+		"https://signoz.internal/api/v1/rules":          false,
+		"https://signoz.eu.corp.example.com/api/v1/dbs": false,
+	} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		u, err := pf(req)
+		if err != nil {
+			t.Fatalf("proxy(%s) error = %v", target, err)
+		}
+		if (u != nil) != proxied {
+			t.Errorf("proxy(%s) = %v, want proxied %v", target, u, proxied)
+		}
+	}
+
+	if _, err := getProxyOptions(context.Background(), nil, &v1beta1.ProxyConfig{URL: "proxy:3128"}); err == nil {
+		t.Error("getProxyOptions() accepted a proxy URL without a host")
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+const (
+	// DefaultQPS and DefaultBurst are the rate limit of ProviderConfigs
+	// that don't set one.
+	DefaultQPS   = 10
+	DefaultBurst = 20
+
+	// defaultRateLimitPause is how long requests to an endpoint pause
+	// after a 429 without a Retry-After.
+	defaultRateLimitPause = time.Second
+)
+
+// RateLimitOptions are the token bucket settings of a Config.
+type RateLimitOptions struct {
+	QPS   int32
+	Burst int32
+}
+
+// limit returns the sustained rate and burst of the options, defaulting
+// unset ones.
+func (o RateLimitOptions) limit() (rate.Limit, int) {
+	qps, burst := o.QPS, o.Burst
+	if qps <= 0 {
+		qps = DefaultQPS
+	}
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	return rate.Limit(qps), int(burst)
+}
+
+// getRateLimitOptions returns the options of a ProviderConfig's rateLimit.
+func getRateLimitOptions(rl *v1beta1.RateLimitConfig) RateLimitOptions {
+	if rl == nil {
+		return RateLimitOptions{}
+	}
+	return RateLimitOptions{QPS: rl.QPS, Burst: rl.Burst}
+}
+
+var (
+	// Token buckets are kept per ProviderConfig and endpoint, and 429
+	// pauses per endpoint. Both outlive Clients, which are rebuilt
+	// whenever their ProviderConfig changes.
+	limitersMu  sync.Mutex
+	limiters    = map[string]*rate.Limiter{}
+	pausedUntil = map[string]time.Time{}
+)
+
+// rateLimitWait is how long requests waited before being sent.
+var rateLimitWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "signoz_client_rate_limit_wait_seconds",
+	Help:    "Time requests to the SigNoz API waited for the client-side rate limiter or a 429 Retry-After deadline.",
+	Buckets: []float64{0.001, 0.01, 0.1, 0.5, 1, 2.5, 5, 15, 30, 60},
+}, []string{"endpoint"})
+
+func init() {
+	metrics.Registry.MustRegister(rateLimitWait)
+}
+
+// limiterFor returns the token bucket of the config's ProviderConfig and
+// endpoint, updated to the config's rate limit.
+func limiterFor(cfg Config) *rate.Limiter {
+	limit, burst := cfg.RateLimit.limit()
+	key := cfg.ProviderConfig + "\x00" + cfg.BaseURL
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	l, ok := limiters[key]
+	if !ok {
+		l = rate.NewLimiter(limit, burst)
+		limiters[key] = l
+		return l
+	}
+	if l.Limit() != limit {
+		l.SetLimit(limit)
+	}
+	if l.Burst() != burst {
+		l.SetBurst(burst)
+	}
+	return l
+}
+
+// pauseEndpoint pauses requests to the endpoint for d, unless they are
+// already paused for longer.
+func pauseEndpoint(endpoint string, d time.Duration) {
+	if d <= 0 {
+		d = defaultRateLimitPause
+	}
+	until := time.Now().Add(d)
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	if until.After(pausedUntil[endpoint]) {
+		pausedUntil[endpoint] = until
+	}
+}
+
+// endpointPause returns how long requests to the endpoint remain paused.
+func endpointPause(endpoint string) time.Duration {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	until, ok := pausedUntil[endpoint]
+	if !ok {
+		return 0
+	}
+	d := time.Until(until)
+	if d <= 0 {
+		delete(pausedUntil, endpoint)
+		return 0
+	}
+	return d
+}
+
+// waitForRateLimit blocks until a request may be sent: until any 429 pause
+// of the endpoint is over and the token bucket has a token. If ctx would
+// expire first it returns a RateLimitedError instead of waiting, so the
+// caller can requeue.
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	r := c.limiter.Reserve()
+	delay := r.Delay()
+	if pause := endpointPause(c.config.BaseURL); pause > delay {
+		delay = pause
+	}
+	if delay <= 0 {
+		rateLimitWait.WithLabelValues(RedactURL(c.config.BaseURL)).Observe(0)
+		return nil
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		r.Cancel()
+		return &RateLimitedError{RetryAfter: delay, Body: "client-side rate limit"}
+	}
+
+	log.FromContext(ctx).V(1).Info("Waiting for the SigNoz rate limit", "delay", delay.String())
+	start := time.Now()
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		rateLimitWait.WithLabelValues(RedactURL(c.config.BaseURL)).Observe(time.Since(start).Seconds())
+		return nil
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestClient_RateLimitPausesEndpoint(t *testing.T) {
+	var requests, limited atomic.Int32
+	limited.Store(1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if limited.Load() == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	defer installBreaker(&fakeAuthBreaker{})()
+
+	cfg := Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890", ProviderConfig: "/a"}
+	err := NewClient(cfg).DeleteChannel(context.Background(), "7")
+	if RetryAfter(err) != time.Second {
+		t.Fatalf("DeleteChannel() error = %v, want a 1s RateLimitedError", err)
+	}
+	limited.Store(0)
+
+	// Callers of another ProviderConfig for the endpoint don't send
+	// requests that would be sent before the deadline...
+	other := cfg
+	other.ProviderConfig = "/b"
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = NewClient(other).DeleteChannel(ctx, "7")
+	if !errors.Is(err, ErrRateLimited) || RetryAfter(err) <= 0 {
+		t.Errorf("DeleteChannel() during the pause error = %v, want ErrRateLimited", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want none during the pause", got)
+	}
+
+	// ...and the others wait for it.
+	start := time.Now()
+	if err := NewClient(other).DeleteChannel(context.Background(), "7"); err != nil {
+		t.Fatalf("DeleteChannel() after the pause error = %v", err)
+	}
+	if waited := time.Since(start); waited < 500*time.Millisecond {
+		t.Errorf("DeleteChannel() waited %s, want until the Retry-After deadline", waited)
+	}
+}
+
+func TestClient_RateLimitTokenBucket(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	defer installBreaker(&fakeAuthBreaker{})()
+
+	cfg := Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890", ProviderConfig: "/bucket", RateLimit: RateLimitOptions{QPS: 1, Burst: 2}}
+	c := NewClient(cfg)
+	for i := 0; i < 2; i++ {
+		if err := c.DeleteChannel(context.Background(), "7"); err != nil {
+			t.Fatalf("DeleteChannel() within the burst error = %v", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.DeleteChannel(ctx, "7"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("DeleteChannel() beyond the burst error = %v, want ErrRateLimited", err)
+	}
+
+	// Clients rebuilt with a new rate limit share the bucket at the new rate.
+	cfg.RateLimit = RateLimitOptions{QPS: 5, Burst: 4}
+	l := NewClient(cfg).limiter
+	if l != c.limiter || l.Limit() != rate.Limit(5) || l.Burst() != 4 {
+		t.Errorf("limiter = %p at %v/%d, want %p at 5/4", l, l.Limit(), l.Burst(), c.limiter)
+	}
+
+	// Unset options take the defaults.
+	if limit, burst := (RateLimitOptions{}).limit(); limit != DefaultQPS || burst != DefaultBurst {
+		t.Errorf("limit() of unset options = %v/%d", limit, burst)
+	}
+}
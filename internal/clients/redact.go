@@ -0,0 +1,237 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Payloads sent to and returned by SigNoz carry notification credentials:
+// Slack and MSTeams webhook URLs, PagerDuty routing keys, OpsGenie API keys
+// and SNS access keys. The helpers in this file mask them before anything
+// derived from a payload or an upstream error is logged, recorded as an
+// event or written to a condition message.
+
+const redactedValue = "<redacted>"
+
+// sensitiveFieldNames are payload field names whose values are always
+// masked, matched against the field name with case, "_" and "-" ignored.
+var sensitiveFieldNames = []string{
+	"password", "secret", "token", "apikey", "routingkey", "integrationkey",
+	"servicekey", "authorization", "credentials", "url", "webhookurl",
+	"apiurl", "accesskey", "secretkey",
+}
+
+// sensitiveFieldSuffixes additionally mask fields such as bearer_token or
+// client_secret.
+var sensitiveFieldSuffixes = []string{"password", "secret", "token"}
+
+var (
+	// urlPattern finds URLs in free text.
+	urlPattern = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>\\]+`)
+
+	// jsonFieldPattern finds "name": "value" pairs in free text, such as
+	// an error quoting a payload that couldn't be parsed.
+	jsonFieldPattern = regexp.MustCompile(`"([A-Za-z0-9_-]+)"\s*:\s*"((?:[^"\\]|\\.)*)"`)
+
+	// assignmentPattern finds name=value pairs in free text, such as form
+	// fields.
+	assignmentPattern = regexp.MustCompile(`\b([A-Za-z0-9_-]+)(=)([^\s&",;]+)`)
+
+	// bearerPattern finds bearer tokens, e.g. in a quoted Authorization
+	// header.
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+)
+
+// isSensitiveField reports whether the value of a payload field must be
+// masked. path may be a dotted field path, of which only the last element
+// counts.
+func isSensitiveField(path string) bool {
+	last := path
+	if i := strings.LastIndex(last, "."); i >= 0 {
+		last = last[i+1:]
+	}
+	if i := strings.Index(last, "["); i >= 0 {
+		last = last[:i]
+	}
+	last = strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(last))
+	for _, name := range sensitiveFieldNames {
+		if last == name {
+			return true
+		}
+	}
+	for _, suffix := range sensitiveFieldSuffixes {
+		if strings.HasSuffix(last, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactJSON returns a JSON payload with the values of sensitive fields
+// masked and every URL in it redacted. Payloads that aren't JSON are
+// redacted as free text.
+func RedactJSON(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return RedactString(string(b))
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return RedactString(string(b))
+	}
+	return string(out)
+}
+
+// redactValue masks a value decoded from JSON in place and returns it.
+func redactValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		for k, e := range x {
+			if isSensitiveField(k) && e != nil && e != "" {
+				x[k] = redactedValue
+				continue
+			}
+			x[k] = redactValue(e)
+		}
+	case []interface{}:
+		for i := range x {
+			x[i] = redactValue(x[i])
+		}
+	case string:
+		return RedactString(x)
+	}
+	return v
+}
+
+// RedactURL masks the parts of a URL that commonly carry secrets: user
+// info, query values and path segments that look like tokens. The scheme,
+// host and descriptive path segments are kept so the URL stays
+// recognisable. Strings that aren't absolute URLs are returned unchanged.
+func RedactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return s
+	}
+	if u.User != nil {
+		u.User = url.User(redactedValue)
+	}
+	segments := strings.Split(u.Path, "/")
+	for i, seg := range segments {
+		if looksLikeToken(seg) {
+			segments[i] = redactedValue
+		}
+	}
+	u.Path = strings.Join(segments, "/")
+	u.RawPath = ""
+	return redactQuery(u)
+}
+
+// redactQuery masks every query value and the fragment of a URL, and
+// renders it with readable placeholders.
+func redactQuery(u *url.URL) string {
+	if u.RawQuery != "" {
+		q := u.Query()
+		for k := range q {
+			q[k] = []string{redactedValue}
+		}
+		u.RawQuery = q.Encode()
+	}
+	if u.Fragment != "" {
+		u.Fragment = redactedValue
+	}
+	return strings.NewReplacer("%3Credacted%3E", redactedValue, "%3credacted%3e", redactedValue).Replace(u.String())
+}
+
+// redactAPIPath masks the query values of a SigNoz API request path. Path
+// segments are kept: they are resource IDs, not secrets.
+func redactAPIPath(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return RedactString(path)
+	}
+	return redactQuery(u)
+}
+
+// looksLikeToken reports whether a URL path segment is likely an ID or
+// secret rather than a word: long, or mixing several letters and digits.
+// Short words with a version digit, such as "webhookb2", are kept.
+func looksLikeToken(seg string) bool {
+	if len(seg) >= 16 {
+		return true
+	}
+	if len(seg) < 8 {
+		return false
+	}
+	var letters, digits int
+	for _, r := range seg {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			letters++
+		}
+	}
+	return letters >= 2 && digits >= 2
+}
+
+// RedactString masks URLs and the values of sensitive fields in free text,
+// such as an error message quoting an upstream response.
+func RedactString(s string) string {
+	s = jsonFieldPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := jsonFieldPattern.FindStringSubmatch(m)
+		if !isSensitiveField(sub[1]) || sub[2] == "" {
+			return m
+		}
+		return `"` + sub[1] + `":"` + redactedValue + `"`
+	})
+	s = urlPattern.ReplaceAllStringFunc(s, RedactURL)
+	s = bearerPattern.ReplaceAllString(s, "$1 "+redactedValue)
+	return assignmentPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := assignmentPattern.FindStringSubmatch(m)
+		if !isSensitiveField(sub[1]) || sub[3] == redactedValue || strings.Contains(sub[3], "://") {
+			return m
+		}
+		return sub[1] + sub[2] + redactedValue
+	})
+}
+
+// redactingRecorder redacts the messages of the events it records.
+type redactingRecorder struct {
+	rec event.Recorder
+}
+
+// NewRedactingRecorder returns an event.Recorder that redacts every event
+// message with RedactString before passing it to rec.
+func NewRedactingRecorder(rec event.Recorder) event.Recorder {
+	return &redactingRecorder{rec: rec}
+}
+
+func (r *redactingRecorder) Event(obj runtime.Object, e event.Event) {
+	e.Message = RedactString(e.Message)
+	r.rec.Event(obj, e)
+}
+
+func (r *redactingRecorder) WithAnnotations(keysAndValues ...string) event.Recorder {
+	return &redactingRecorder{rec: r.rec.WithAnnotations(keysAndValues...)}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// authRequeueJitter spreads the requeues of resources that failed auth
+// together by up to this fraction of their delay, so they don't retry in
+// lockstep once the credentials are fixed.
+const authRequeueJitter = 0.2
+
+// upstreamOutcomeKey is the context key of a reconcile's upstreamOutcome.
+type upstreamOutcomeKey struct{}
+
+// upstreamOutcome is the last SigNoz API error a reconcile ran into.
+type upstreamOutcome struct {
+	mu  sync.Mutex
+	err error
+}
+
+// noteUpstreamOutcome records a failed SigNoz API call for the
+// BackoffReconciler of ctx, if any. Successful calls are ignored, so a
+// best-effort call that follows a failure doesn't hide it.
+func noteUpstreamOutcome(ctx context.Context, err error) {
+	o, ok := ctx.Value(upstreamOutcomeKey{}).(*upstreamOutcome)
+	if !ok || Classify(err) == ClassNone {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.err = err
+}
+
+// A BackoffReconciler requeues the resources of a managed reconciler by
+// the class of the SigNoz API error their reconcile last ran into, per
+// its BackoffPolicy: auth failures after a long, jittered delay,
+// transient failures after a short one, and 429s after their Retry-After.
+// Without it a managed reconciler retries every failure on the generic
+// rate limiter, every few seconds per resource.
+type BackoffReconciler struct {
+	inner  reconcile.Reconciler
+	policy BackoffPolicy
+
+	mu       sync.Mutex
+	failures map[string]int
+}
+
+// NewBackoffReconciler wraps r, usually a managed.Reconciler, to requeue by
+// policy. Wrap the result in a ratelimiter.Reconciler, not the other way
+// round: requests the global rate limiter holds back must not count as
+// successful reconciles and reset the failure count.
+func NewBackoffReconciler(r reconcile.Reconciler, policy BackoffPolicy) *BackoffReconciler {
+	return &BackoffReconciler{inner: r, policy: policy, failures: map[string]int{}}
+}
+
+// Reconcile the request with the wrapped reconciler. If the reconcile
+// failed, requeue it after the delay the policy recommends for the last
+// SigNoz API error it ran into. Reconciles that succeeded, including those
+// of objects that are gone, or that failed without an API error are
+// returned as is.
+func (r *BackoffReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	o := &upstreamOutcome{}
+	result, err := r.inner.Reconcile(context.WithValue(ctx, upstreamOutcomeKey{}, o), req)
+
+	key := req.String()
+	// A managed reconciler reports failures by asking for an immediate
+	// requeue; errors of best-effort calls don't fail the reconcile.
+	if !result.Requeue && err == nil {
+		r.mu.Lock()
+		delete(r.failures, key)
+		r.mu.Unlock()
+		return result, err
+	}
+
+	o.mu.Lock()
+	upstreamErr := o.err
+	o.mu.Unlock()
+	if upstreamErr == nil {
+		return result, err
+	}
+	class := Classify(upstreamErr)
+
+	r.mu.Lock()
+	r.failures[key]++
+	failures := r.failures[key]
+	r.mu.Unlock()
+
+	_, delay := Backoff(upstreamErr, failures, r.policy)
+	if class == ClassAuth {
+		delay = jitterDown(delay, authRequeueJitter)
+	}
+	if delay <= 0 {
+		return result, err
+	}
+	log.FromContext(ctx).V(1).Info("Upstream error; requeueing",
+		"request", key,
+		"class", class.String(),
+		"consecutive_failures", failures,
+		"in", delay.String())
+	return reconcile.Result{RequeueAfter: delay}, err
+}
+
+// jitterDown returns d less a random fraction of up to factor of it, so a
+// jittered delay never exceeds its policy's cap.
+func jitterDown(d time.Duration, factor float64) time.Duration {
+	return 2*d - wait.Jitter(d, factor)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// managedReconciler stands in for a managed.Reconciler: it swallows the
+// error of its external client and asks for a plain requeue. Errors of
+// best-effort calls are ignored, and objects that are gone aren't
+// requeued.
+type managedReconciler struct {
+	c          *Client
+	bestEffort bool
+	gone       bool
+}
+
+func (r *managedReconciler) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	if r.gone {
+		return reconcile.Result{}, nil
+	}
+	if r.c == nil {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	}
+	if err := r.c.DeleteChannel(ctx, "7"); err != nil && !r.bestEffort {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	return reconcile.Result{RequeueAfter: time.Minute}, nil
+}
+
+func TestBackoffReconciler(t *testing.T) {
+	status, retryAfter := http.StatusOK, ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(status)
+	}))
+	defer ts.Close()
+	rec := &fakeAuthBreaker{}
+	defer installBreaker(rec)()
+
+	policy := BackoffPolicy{
+		AuthRequeueMin:   5 * time.Minute,
+		AuthRequeueMax:   15 * time.Minute,
+		TransientInitial: time.Second,
+		TransientCap:     time.Minute,
+		RateLimitedCap:   5 * time.Minute,
+	}
+	c := NewClient(Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890", ProviderConfig: "/requeue"})
+	inner := &managedReconciler{c: c}
+	r := NewBackoffReconciler(inner, policy)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "alert"}}
+	reconcileAfter := func() time.Duration {
+		t.Helper()
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		return result.RequeueAfter
+	}
+
+	// Transient failures back off exponentially from TransientInitial.
+	status = http.StatusServiceUnavailable
+	if got := reconcileAfter(); got != time.Second {
+		t.Errorf("first transient requeue = %s, want 1s", got)
+	}
+	if got := reconcileAfter(); got != 2*time.Second {
+		t.Errorf("second transient requeue = %s, want 2s", got)
+	}
+
+	// Success leaves the managed reconciler's result alone and resets the
+	// count.
+	status = http.StatusOK
+	if got := reconcileAfter(); got != time.Minute {
+		t.Errorf("requeue after success = %s, want the poll interval", got)
+	}
+
+	// Auth failures requeue after a jittered AuthRequeueMin, as do calls
+	// rejected by an open breaker.
+	status = http.StatusUnauthorized
+	if got := reconcileAfter(); got < 4*time.Minute || got > 5*time.Minute {
+		t.Errorf("auth requeue = %s, want 4-5m", got)
+	}
+	rec.allowError = errors.New("breaker is open: upstream authentication is failing")
+	if got := reconcileAfter(); got < 8*time.Minute || got > 10*time.Minute {
+		t.Errorf("breaker-open requeue = %s, want 8-10m", got)
+	}
+	rec.allowError = nil
+
+	// 429s requeue after their Retry-After.
+	status, retryAfter = http.StatusTooManyRequests, "42"
+	if got := reconcileAfter(); got != 42*time.Second {
+		t.Errorf("rate-limited requeue = %s, want 42s", got)
+	}
+
+	// Failed best-effort calls don't override a successful reconcile.
+	status, retryAfter = http.StatusServiceUnavailable, ""
+	inner.bestEffort = true
+	if got := reconcileAfter(); got != time.Minute {
+		t.Errorf("requeue after a failed best-effort call = %s, want the poll interval", got)
+	}
+
+	// The failure count of objects that are gone is forgotten.
+	inner.bestEffort = false
+	_ = reconcileAfter()
+	inner.gone = true
+	if got := reconcileAfter(); got != 0 {
+		t.Errorf("requeue of a gone object = %s, want none", got)
+	}
+	if len(r.failures) != 0 {
+		t.Errorf("failures = %v, want none", r.failures)
+	}
+
+	// Reconciles without API calls are returned as is.
+	r = NewBackoffReconciler(&managedReconciler{}, policy)
+	if got := reconcileAfter(); got != time.Minute {
+		t.Errorf("requeue without API calls = %s, want the inner result", got)
+	}
+}
+
+// throttle is a global rate limiter that holds every request for delay.
+type throttle struct{ delay time.Duration }
+
+func (l *throttle) When(string) time.Duration { return l.delay }
+func (l *throttle) Forget(string)             {}
+func (l *throttle) NumRequeues(string) int    { return 0 }
+
+// Requests held by the global rate limiter never reach the
+// BackoffReconciler, so they don't reset its failure count.
+func TestBackoffReconcilerRateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	defer installBreaker(&fakeAuthBreaker{})()
+
+	policy := BackoffPolicy{TransientInitial: time.Second, TransientCap: time.Minute}
+	c := NewClient(Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890", ProviderConfig: "/requeue-limited"})
+	limiter := &throttle{}
+	r := ratelimiter.NewReconciler("alert", NewBackoffReconciler(&managedReconciler{c: c}, policy), limiter)
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "alert"}}
+
+	for _, tc := range []struct {
+		delay time.Duration
+		want  time.Duration
+	}{
+		{delay: 0, want: time.Second},
+		{delay: time.Hour, want: time.Hour},
+		{delay: 0, want: 2 * time.Second},
+	} {
+		limiter.delay = tc.delay
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if result.RequeueAfter != tc.want {
+			t.Errorf("requeue with the limiter holding for %s = %s, want %s", tc.delay, result.RequeueAfter, tc.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+// Credentials may hold a nextApiKey alongside the apiKey so the key can be
+// rotated without downtime: create the new key in SigNoz, add it as
+// nextApiKey, revoke the old key, then promote nextApiKey to apiKey. Every
+// Client of the credentials switches to the key SigNoz last accepted when
+// the other is rejected.
+
+var (
+	// activeKeys records which key of each pair of credentials SigNoz last
+	// accepted. It outlives Clients, which are rebuilt whenever their
+	// ProviderConfig changes.
+	activeKeysMu sync.Mutex
+	activeKeys   = map[string]v1beta1.APIKeySlot{}
+
+	// keyFallbackHandler is told about Clients that fell back from apiKey
+	// to nextApiKey.
+	keyFallbackHandler func(providerConfig string)
+)
+
+// SetAPIKeyFallbackHandler installs fn to be called with the name of the
+// ProviderConfig whenever a Client switches from the credentials' apiKey to
+// their nextApiKey. fn must not block.
+func SetAPIKeyFallbackHandler(fn func(providerConfig string)) {
+	activeKeysMu.Lock()
+	defer activeKeysMu.Unlock()
+	keyFallbackHandler = fn
+}
+
+// rotationKey identifies the key pair of the config.
+func (c *Client) rotationKey() string {
+	return breakerKey(c.config.BaseURL, c.config.APIKey+"\x00"+c.config.NextAPIKey)
+}
+
+// ActiveAPIKey returns the key of the credentials the Client authenticates
+// with, or "" if the credentials have no nextApiKey.
+func (c *Client) ActiveAPIKey() v1beta1.APIKeySlot {
+	if c.config.AuthMode == v1beta1.AuthModePassword || c.config.NextAPIKey == "" {
+		return ""
+	}
+	activeKeysMu.Lock()
+	defer activeKeysMu.Unlock()
+	if slot, ok := activeKeys[c.rotationKey()]; ok {
+		return slot
+	}
+	return v1beta1.APIKeySlotPrimary
+}
+
+// apiKey returns the value of the given key.
+func (c *Client) apiKey(slot v1beta1.APIKeySlot) string {
+	if slot == v1beta1.APIKeySlotSecondary {
+		return c.config.NextAPIKey
+	}
+	return c.config.APIKey
+}
+
+// activate records that SigNoz accepted the given key after rejecting the
+// other.
+func (c *Client) activate(ctx context.Context, slot v1beta1.APIKeySlot) {
+	activeKeysMu.Lock()
+	prev, ok := activeKeys[c.rotationKey()]
+	activeKeys[c.rotationKey()] = slot
+	handler := keyFallbackHandler
+	activeKeysMu.Unlock()
+
+	if !ok {
+		prev = v1beta1.APIKeySlotPrimary
+	}
+	if prev == slot {
+		return
+	}
+	log.FromContext(ctx).Info("API key rejected; switched to the other key of the credentials", "active", string(slot))
+	if slot == v1beta1.APIKeySlotSecondary && handler != nil && c.config.ProviderConfig != "" {
+		handler(c.config.ProviderConfig)
+	}
+}
+
+// sendWithAPIKey makes a request with the active API key. If SigNoz
+// rejects it with a 401 and the credentials have another key, the request
+// is retried once with that key, which becomes active if it is accepted.
+func (c *Client) sendWithAPIKey(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	slot := c.ActiveAPIKey()
+	if slot == "" {
+		return c.sendOnce(ctx, method, path, body, "SIGNOZ-API-KEY", c.config.APIKey)
+	}
+	resp, err := c.sendOnce(ctx, method, path, body, "SIGNOZ-API-KEY", c.apiKey(slot))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	other := v1beta1.APIKeySlotSecondary
+	if slot == v1beta1.APIKeySlotSecondary {
+		other = v1beta1.APIKeySlotPrimary
+	}
+	resp, err = c.sendOnce(ctx, method, path, body, "SIGNOZ-API-KEY", c.apiKey(other))
+	if err == nil && resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		c.activate(ctx, other)
+	}
+	return resp, err
+}
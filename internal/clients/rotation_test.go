@@ -0,0 +1,100 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+func TestClient_APIKeyRotation(t *testing.T) {
+	accepted := "new-api-key-1234567890"
+	seen := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("SIGNOZ-API-KEY")
+		seen[key]++
+		if key != accepted {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	rec := &fakeAuthBreaker{}
+	defer installBreaker(rec)()
+
+	var fallbacks []string
+	SetAPIKeyFallbackHandler(func(pc string) { fallbacks = append(fallbacks, pc) })
+	defer SetAPIKeyFallbackHandler(nil)
+
+	cfg := Config{
+		BaseURL:        ts.URL,
+		APIKey:         "old-api-key-1234567890",
+		NextAPIKey:     "new-api-key-1234567890",
+		ProviderConfig: "/default",
+	}
+	c := NewClient(cfg)
+	if got := c.ActiveAPIKey(); got != v1beta1.APIKeySlotPrimary {
+		t.Errorf("ActiveAPIKey() = %q before any request, want Primary", got)
+	}
+
+	// The revoked primary is tried once, then the secondary is used.
+	for i := 0; i < 3; i++ {
+		if err := c.DeleteChannel(context.Background(), "7"); err != nil {
+			t.Fatalf("DeleteChannel() error = %v", err)
+		}
+	}
+	if seen[cfg.APIKey] != 1 || seen[cfg.NextAPIKey] != 3 {
+		t.Errorf("requests per key = %v, want the primary tried once", seen)
+	}
+	if got := c.ActiveAPIKey(); got != v1beta1.APIKeySlotSecondary {
+		t.Errorf("ActiveAPIKey() = %q, want Secondary", got)
+	}
+	if len(fallbacks) != 1 || fallbacks[0] != "/default" {
+		t.Errorf("fallback handler called with %v, want [/default] once", fallbacks)
+	}
+	for _, authFailure := range rec.recordedAuth {
+		if authFailure {
+			t.Errorf("breaker recorded an auth failure: %v", rec.recordedAuth)
+		}
+	}
+
+	// Clients rebuilt from the same credentials keep using the secondary.
+	if got := NewClient(cfg).ActiveAPIKey(); got != v1beta1.APIKeySlotSecondary {
+		t.Errorf("ActiveAPIKey() of a new Client = %q, want Secondary", got)
+	}
+
+	// When both keys are rejected the breaker sees the auth failure.
+	accepted = "neither"
+	rec.recordedAuth = nil
+	if err := c.DeleteChannel(context.Background(), "7"); !errors.Is(err, ErrAuth) {
+		t.Errorf("DeleteChannel() error = %v, want ErrAuth", err)
+	}
+	if len(rec.recordedAuth) != 1 || !rec.recordedAuth[0] {
+		t.Errorf("breaker recorded %v, want one auth failure", rec.recordedAuth)
+	}
+
+	// Without a nextApiKey there is nothing to report.
+	if got := NewClient(Config{BaseURL: ts.URL, APIKey: cfg.APIKey}).ActiveAPIKey(); got != "" {
+		t.Errorf("ActiveAPIKey() without nextApiKey = %q, want unset", got)
+	}
+}
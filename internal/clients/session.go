@@ -0,0 +1,217 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// loginPath is the SigNoz endpoint that exchanges an email and password
+// for a JWT session.
+const loginPath = "/api/v1/login"
+
+const (
+	// tokenRefreshBefore is how long before its expiry an access token is
+	// replaced, so a request never races the expiry.
+	tokenRefreshBefore = time.Minute
+
+	// defaultTokenLifetime is assumed for access tokens whose expiry
+	// SigNoz didn't report.
+	defaultTokenLifetime = 10 * time.Minute
+)
+
+const (
+	errLogin          = "cannot log in to SigNoz"
+	errDecodeLogin    = "cannot decode SigNoz login response"
+	errNoAccessToken  = "SigNoz login response has no access token"
+	errEmptyLoginCred = "signoz credentials are missing an email or password (misconfigured ProviderConfig or empty secret)"
+)
+
+// sendWithSession makes a request with the session's access token. A 401
+// to a token that wasn't issued for this request logs in again and
+// retries once, so an expired or revoked session isn't reported as an auth
+// failure; a 401 after a fresh login is.
+func (c *Client) sendWithSession(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	token, fresh, err := c.session.accessToken(ctx, c.login)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.sendOnce(ctx, method, path, body, "Authorization", "Bearer "+token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || fresh {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+
+	log.FromContext(ctx).V(1).Info("Access token rejected; logging in again")
+	c.session.invalidate(token)
+	token, _, err = c.session.accessToken(ctx, c.login)
+	if err != nil {
+		return nil, err
+	}
+	return c.sendOnce(ctx, method, path, body, "Authorization", "Bearer "+token)
+}
+
+// loginRequest is the body of a SigNoz login request.
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// userJWT is the session SigNoz returns on login.
+type userJWT struct {
+	AccessJWT       string `json:"accessJwt"`
+	AccessJWTExpiry int64  `json:"accessJwtExpiry"`
+}
+
+// loginResponse is the body of a SigNoz login response. Older versions
+// return it as is, newer ones wrap it in the usual data envelope.
+type loginResponse struct {
+	UserJWT userJWT `json:"userJwt"`
+	Data    *struct {
+		UserJWT userJWT `json:"userJwt"`
+	} `json:"data"`
+}
+
+// A session holds the access token of a Password mode Client. It is shared
+// by every request of the Client, and so by every reconcile that uses the
+// cached Client.
+type session struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+func newSession() *session {
+	return &session{now: time.Now}
+}
+
+// accessToken returns a token valid for at least tokenRefreshBefore,
+// logging in with login if the session has none. fresh reports whether the
+// token was just issued.
+func (s *session) accessToken(ctx context.Context, login func(context.Context) (string, time.Time, error)) (token string, fresh bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && s.now().Before(s.expiry.Add(-tokenRefreshBefore)) {
+		return s.token, false, nil
+	}
+	token, expiry, err := login(ctx)
+	if err != nil {
+		s.token = ""
+		return "", false, err
+	}
+	s.token, s.expiry = token, expiry
+	return token, true, nil
+}
+
+// invalidate drops the token so the next request logs in again.
+func (s *session) invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Another request may have replaced the rejected token already.
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// login exchanges the configured email and password for an access token
+// and its expiry. A rejected login returns an APIError matching ErrAuth.
+func (c *Client) login(ctx context.Context) (string, time.Time, error) {
+	log.FromContext(ctx).V(1).Info("Logging in to SigNoz", "url", RedactURL(c.config.BaseURL)+loginPath)
+
+	body, err := json.Marshal(loginRequest{Email: c.config.Email, Password: c.config.Password})
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errLogin)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.BaseURL+loginPath, bytes.NewReader(body))
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errLogin)
+	}
+	for name, value := range c.config.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errLogin)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 400 {
+		return "", time.Time{}, errors.Wrap(newAPIError(http.MethodPost, loginPath, resp.StatusCode, respBody), errLogin)
+	}
+
+	var lr loginResponse
+	if err := json.Unmarshal(respBody, &lr); err != nil {
+		return "", time.Time{}, errors.Wrap(err, errDecodeLogin)
+	}
+	jwt := lr.UserJWT
+	if lr.Data != nil && lr.Data.UserJWT.AccessJWT != "" {
+		jwt = lr.Data.UserJWT
+	}
+	if jwt.AccessJWT == "" {
+		return "", time.Time{}, errors.New(errNoAccessToken)
+	}
+	return jwt.AccessJWT, c.tokenExpiry(jwt), nil
+}
+
+// tokenExpiry returns when an access token expires: as reported by SigNoz,
+// else from the token's exp claim, else after defaultTokenLifetime.
+func (c *Client) tokenExpiry(jwt userJWT) time.Time {
+	if jwt.AccessJWTExpiry > 0 {
+		return time.Unix(jwt.AccessJWTExpiry, 0)
+	}
+	if exp := jwtExpiry(jwt.AccessJWT); !exp.IsZero() {
+		return exp
+	}
+	return c.session.now().Add(defaultTokenLifetime)
+}
+
+// jwtExpiry returns the exp claim of a JWT, or the zero time if it has
+// none. The signature isn't verified: the token is only passed back to the
+// SigNoz that issued it.
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if json.Unmarshal(payload, &claims) != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
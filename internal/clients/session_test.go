@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+// fakeLoginServer is a SigNoz that issues access tokens on login and
+// accepts only the most recently issued one.
+type fakeLoginServer struct {
+	mu       sync.Mutex
+	logins   int
+	current  string
+	lifetime time.Duration
+	wrapped  bool
+}
+
+func (s *fakeLoginServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path == loginPath {
+		var req loginRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Email != "admin@example.com" || req.Password != "hunter22" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"status":"error","error":"invalid credentials"}`))
+			return
+		}
+		s.logins++
+		s.current = fmt.Sprintf("token-%d", s.logins)
+		jwt := fmt.Sprintf(`{"accessJwt":%q,"accessJwtExpiry":%d}`, s.current, time.Now().Add(s.lifetime).Unix())
+		if s.wrapped {
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"userJwt":%s}}`, jwt)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"userJwt":%s,"userId":"u1"}`, jwt)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+s.current {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// revoke makes the server reject the current token, as if the session was
+// logged out.
+func (s *fakeLoginServer) revoke() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = "revoked"
+}
+
+func passwordConfig(url string) Config {
+	return Config{BaseURL: url, AuthMode: v1beta1.AuthModePassword, Email: "admin@example.com", Password: "hunter22"}
+}
+
+func TestClient_PasswordSession(t *testing.T) {
+	srv := &fakeLoginServer{lifetime: time.Hour, wrapped: true}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	rec := &fakeAuthBreaker{}
+	defer installBreaker(rec)()
+
+	c := NewClient(passwordConfig(ts.URL))
+	for i := 0; i < 3; i++ {
+		if err := c.DeleteChannel(context.Background(), "7"); err != nil {
+			t.Fatalf("DeleteChannel() error = %v", err)
+		}
+	}
+	if srv.logins != 1 {
+		t.Errorf("logged in %d times, want the token reused", srv.logins)
+	}
+
+	// A token close to expiry is replaced before it is used.
+	c.session.now = func() time.Time { return time.Now().Add(time.Hour - 30*time.Second) }
+	if err := c.DeleteChannel(context.Background(), "7"); err != nil {
+		t.Fatalf("DeleteChannel() error = %v", err)
+	}
+	if srv.logins != 2 {
+		t.Errorf("logged in %d times, want a refresh before expiry", srv.logins)
+	}
+	c.session.now = time.Now
+
+	// A revoked token is replaced and the request retried once, without
+	// the breaker seeing an auth failure.
+	srv.revoke()
+	if err := c.ProbeCredentials(context.Background()); err != nil {
+		t.Fatalf("ProbeCredentials() after revocation error = %v", err)
+	}
+	if srv.logins != 3 {
+		t.Errorf("logged in %d times, want a login after the 401", srv.logins)
+	}
+	for _, authFailure := range rec.recordedAuth {
+		if authFailure {
+			t.Errorf("breaker recorded an auth failure: %v", rec.recordedAuth)
+		}
+	}
+}
+
+func TestClient_PasswordSessionAuthFailures(t *testing.T) {
+	srv := &fakeLoginServer{lifetime: time.Hour}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	rec := &fakeAuthBreaker{}
+	defer installBreaker(rec)()
+
+	// A rejected login is an auth failure.
+	cfg := passwordConfig(ts.URL)
+	cfg.Password = "wrong"
+	err := NewClient(cfg).DeleteChannel(context.Background(), "7")
+	if !errors.Is(err, ErrAuth) {
+		t.Errorf("DeleteChannel() error = %v, want ErrAuth", err)
+	}
+	if len(rec.recordedAuth) != 1 || !rec.recordedAuth[0] {
+		t.Errorf("breaker recorded %v, want one auth failure", rec.recordedAuth)
+	}
+
+	// So is a 401 to a freshly issued token, which isn't retried.
+	rec.recordedAuth = nil
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == loginPath {
+			srv.ServeHTTP(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	err = NewClient(passwordConfig(ts.URL)).DeleteChannel(context.Background(), "7")
+	if !errors.Is(err, ErrAuth) {
+		t.Errorf("DeleteChannel() error = %v, want ErrAuth", err)
+	}
+	if srv.logins != 1 {
+		t.Errorf("logged in %d times, want no retry after a fresh login", srv.logins)
+	}
+	if len(rec.recordedAuth) != 1 || !rec.recordedAuth[0] {
+		t.Errorf("breaker recorded %v, want one auth failure", rec.recordedAuth)
+	}
+
+	// Missing credentials are rejected before any request.
+	cfg.Password = ""
+	if err := NewClient(cfg).DeleteChannel(context.Background(), "7"); err == nil || err.Error() != errNoLoginCredentials {
+		t.Errorf("DeleteChannel() error = %v, want %q", err, errNoLoginCredentials)
+	}
+}
+
+func TestJWTExpiry(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1767225600,"sub":"u1"}`))
+	if got := jwtExpiry("header." + payload + ".sig"); !got.Equal(time.Unix(1767225600, 0)) {
+		t.Errorf("jwtExpiry() = %v", got)
+	}
+	if got := jwtExpiry("opaque-token"); !got.IsZero() {
+		t.Errorf("jwtExpiry(opaque) = %v, want zero", got)
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -34,6 +33,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rossigee/provider-signoz/apis/v1beta1"
 
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -65,6 +65,7 @@ const (
 	errEmptyAPIKey          = "signoz credentials are missing an apiKey (misconfigured ProviderConfig or empty secret)"
 	errShortAPIKey          = "signoz credentials apiKey is shorter than the configured minimum"
 	errNoCredentials        = "refusing to call Signoz API: apiKey is empty"
+	errNoLoginCredentials   = "refusing to call Signoz API: email or password is empty"
 )
 
 // Sentinel errors returned by API calls. Callers (controllers) branch on these
@@ -77,10 +78,11 @@ var (
 
 // RateLimitedError is returned when the upstream Signoz API returns HTTP 429.
 // It carries the Retry-After duration when the upstream provides one so callers
-// can honour it instead of using a generic backoff.
+// can honour it instead of using a generic backoff, and wraps the APIError.
 type RateLimitedError struct {
 	RetryAfter time.Duration
 	Body       string
+	Err        *APIError
 }
 
 func (e *RateLimitedError) Error() string {
@@ -96,6 +98,14 @@ func (e *RateLimitedError) Is(target error) bool {
 	return target == ErrRateLimited
 }
 
+// Unwrap returns the APIError of the 429 response, if any.
+func (e *RateLimitedError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
+
 // MinAPIKeyLength is the minimum acceptable length of an extracted Signoz
 // apiKey. Keys shorter than this are almost certainly a misconfiguration
 // (empty secret, placeholder, wrong key) and are rejected before any
@@ -156,33 +166,98 @@ func breakerKey(baseURL, apiKey string) string {
 
 // Config holds SigNoz client configuration
 type Config struct {
-	BaseURL               string
-	APIKey                string
+	BaseURL string
+	APIKey  string
+
+	// NextAPIKey is used when APIKey is rejected, to rotate keys.
+	NextAPIKey string
+
+	// AuthMode selects APIKey or, with Email and Password, login-based
+	// JWT session authentication. Empty means APIKey.
+	AuthMode v1beta1.AuthMode
+	Email    string
+	Password string
+
 	InsecureSkipTLSVerify bool
+	TLS                   TLSOptions
+	Proxy                 ProxyOptions
+
+	// Headers are added to every request.
+	Headers map[string]string
+
+	// RateLimit is the token bucket of requests to the endpoint.
+	RateLimit RateLimitOptions
+
+	// ProviderConfig and Revision identify the ProviderConfig the config
+	// was read from, for ClientCache. Revision changes whenever the
+	// ProviderConfig does.
+	ProviderConfig string
+	Revision       string
+
+	// Capabilities of the server, as recorded in the ProviderConfig's
+	// status.
+	Capabilities Capabilities
+}
+
+// credentialKey identifies the credentials of the config for the auth
+// breaker and the client cache. It is never logged.
+func (cfg Config) credentialKey() string {
+	if cfg.AuthMode == v1beta1.AuthModePassword {
+		return string(cfg.AuthMode) + "\x00" + cfg.Email + "\x00" + cfg.Password
+	}
+	if cfg.NextAPIKey != "" {
+		return cfg.APIKey + "\x00" + cfg.NextAPIKey
+	}
+	return cfg.APIKey
+}
+
+// credentialsError returns the reason requests can't be authenticated, or
+// nil if the config has the credentials of its AuthMode.
+func (cfg Config) credentialsError() error {
+	if cfg.AuthMode == v1beta1.AuthModePassword {
+		if cfg.Email == "" || cfg.Password == "" {
+			return errors.New(errNoLoginCredentials)
+		}
+		return nil
+	}
+	if cfg.APIKey == "" {
+		return errors.New(errNoCredentials)
+	}
+	return nil
 }
 
 // Credentials holds SigNoz authentication credentials
 type Credentials struct {
-	APIKey string `json:"apiKey"`
+	APIKey     string `json:"apiKey"`
+	NextAPIKey string `json:"nextApiKey"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
 }
 
 // Client is a SigNoz API client
 type Client struct {
 	config     Config
 	httpClient *http.Client
+
+	// session holds the access token in Password mode.
+	session *session
+
+	// limiter is the token bucket of the ProviderConfig's endpoint.
+	limiter *rate.Limiter
 }
 
-// NewClient creates a new SigNoz API client
+// NewClient creates a new SigNoz API client. Clients share a transport,
+// and so their connection pool, with every other Client that has the same
+// connection settings. Use CachedClient to also reuse the Client itself.
 func NewClient(cfg Config) *Client {
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.InsecureSkipTLSVerify}, //nolint:gosec // opt-in via ProviderConfig
-	}
 	return &Client{
 		config: cfg,
 		httpClient: &http.Client{
 			Timeout:   30 * time.Second,
-			Transport: transport,
+			Transport: sharedTransport(cfg),
 		},
+		session: newSession(),
+		limiter: limiterFor(cfg),
 	}
 }
 
@@ -215,18 +290,9 @@ func GetConfig(ctx context.Context, c resource.ClientApplicator, mg resource.Man
 		return nil, errors.New(errGetProviderConfig)
 	}
 
-	pc := &v1beta1.ProviderConfig{}
-	// Try cluster-scoped first (newer Crossplane), then namespace-scoped
-	logger := log.FromContext(ctx)
-	logger.V(1).Info("DEBUG GetConfig: attempting cluster-scoped ProviderConfig lookup", "name", pcRef.Name, "namespace", "")
-	if err := c.Get(ctx, types.NamespacedName{Name: pcRef.Name}, pc); err != nil {
-		logger.V(1).Info("DEBUG GetConfig: cluster-scoped lookup failed, trying namespace-scoped", "error", err, "namespace", mg.GetNamespace())
-		// If not found cluster-scoped, try with the managed resource's namespace
-		if err := c.Get(ctx, types.NamespacedName{Namespace: mg.GetNamespace(), Name: pcRef.Name}, pc); err != nil {
-			logger.V(1).Info("DEBUG GetConfig: namespace-scoped lookup also failed", "error", err)
-			return nil, errors.Wrap(err, errGetProviderConfig)
-		}
-		logger.V(1).Info("DEBUG GetConfig: namespace-scoped lookup succeeded")
+	pc, err := getProviderConfig(ctx, c.Client, mg.GetNamespace(), pcRef.Name)
+	if err != nil {
+		return nil, err
 	}
 
 	// Use no-op tracker for xpv1.0.0 compatibility
@@ -238,6 +304,37 @@ func GetConfig(ctx context.Context, c resource.ClientApplicator, mg resource.Man
 	return GetConfigFromProviderConfig(ctx, c.Client, pc)
 }
 
+// GetConfigByReference extracts SigNoz configuration from the referenced
+// ProviderConfig. Used by controllers of resources that aren't managed
+// resources but still call SigNoz.
+func GetConfigByReference(ctx context.Context, c client.Client, namespace string, ref *xpv1.ProviderConfigReference) (*Config, error) {
+	if ref == nil {
+		return nil, errors.New(errNoProviderConfig)
+	}
+	pc, err := getProviderConfig(ctx, c, namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	return GetConfigFromProviderConfig(ctx, c, pc)
+}
+
+// getProviderConfig gets a ProviderConfig by name, trying cluster-scoped
+// first (newer Crossplane), then the given namespace.
+func getProviderConfig(ctx context.Context, c client.Client, namespace, name string) (*v1beta1.ProviderConfig, error) {
+	pc := &v1beta1.ProviderConfig{}
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("DEBUG GetConfig: attempting cluster-scoped ProviderConfig lookup", "name", name, "namespace", "")
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		logger.V(1).Info("DEBUG GetConfig: cluster-scoped lookup failed, trying namespace-scoped", "error", err, "namespace", namespace)
+		if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pc); err != nil {
+			logger.V(1).Info("DEBUG GetConfig: namespace-scoped lookup also failed", "error", err)
+			return nil, errors.Wrap(err, errGetProviderConfig)
+		}
+		logger.V(1).Info("DEBUG GetConfig: namespace-scoped lookup succeeded")
+	}
+	return pc, nil
+}
+
 // GetConfigFromProviderConfig extracts Signoz Config from an already-loaded
 // ProviderConfig object. Used by the ProviderConfig reconciler which doesn't
 // have a managed resource to reference — and is the single source of truth
@@ -253,11 +350,23 @@ func GetConfigFromProviderConfig(ctx context.Context, c client.Client, pc *v1bet
 		return nil, errors.Wrap(err, errUnmarshalCredentials)
 	}
 
-	if creds.APIKey == "" {
-		return nil, errors.New(errEmptyAPIKey)
-	}
-	if MinAPIKeyLength > 0 && len(creds.APIKey) < MinAPIKeyLength {
-		return nil, errors.Errorf("%s (got %d chars, need >= %d)", errShortAPIKey, len(creds.APIKey), MinAPIKeyLength)
+	mode := pc.Spec.Credentials.AuthMode
+	switch mode {
+	case v1beta1.AuthModePassword:
+		if creds.Email == "" || creds.Password == "" {
+			return nil, errors.New(errEmptyLoginCred)
+		}
+	default:
+		mode = v1beta1.AuthModeAPIKey
+		if creds.APIKey == "" {
+			return nil, errors.New(errEmptyAPIKey)
+		}
+		if MinAPIKeyLength > 0 && len(creds.APIKey) < MinAPIKeyLength {
+			return nil, errors.Errorf("%s (got %d chars, need >= %d)", errShortAPIKey, len(creds.APIKey), MinAPIKeyLength)
+		}
+		if creds.NextAPIKey != "" && MinAPIKeyLength > 0 && len(creds.NextAPIKey) < MinAPIKeyLength {
+			return nil, errors.Errorf("%s (nextApiKey: got %d chars, need >= %d)", errShortAPIKey, len(creds.NextAPIKey), MinAPIKeyLength)
+		}
 	}
 
 	endpoint := "https://api.signoz.cloud"
@@ -270,60 +379,82 @@ func GetConfigFromProviderConfig(ctx context.Context, c client.Client, pc *v1bet
 		skipTLS = *pc.Spec.InsecureSkipTLSVerify
 	}
 
+	tlsOpts, err := getTLSOptions(ctx, c, pc.Spec.TLS)
+	if err != nil {
+		return nil, err
+	}
+	proxyOpts, err := getProxyOptions(ctx, c, pc.Spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		BaseURL:               strings.TrimSuffix(endpoint, "/"),
 		APIKey:                creds.APIKey,
+		NextAPIKey:            creds.NextAPIKey,
+		AuthMode:              mode,
+		Email:                 creds.Email,
+		Password:              creds.Password,
 		InsecureSkipTLSVerify: skipTLS,
+		TLS:                   tlsOpts,
+		Proxy:                 proxyOpts,
+		Headers:               pc.Spec.Headers,
+		RateLimit:             getRateLimitOptions(pc.Spec.RateLimit),
+		ProviderConfig:        client.ObjectKeyFromObject(pc).String(),
+		Revision:              string(pc.GetUID()) + "/" + pc.GetResourceVersion(),
+		Capabilities:          Capabilities{Version: pc.Status.ServerVersion, Features: pc.Status.FeatureFlags},
 	}, nil
 }
 
 // doRequest performs an HTTP request with authentication.
 //
-// If the client is misconfigured with an empty APIKey, the call is rejected
+// If the client is misconfigured with empty credentials, the call is rejected
 // before any TCP connection is opened. This prevents a single bad ProviderConfig
 // from hammering the upstream Signoz API with unauthenticated traffic.
 //
 // For probe calls (used by ProviderConfig credentials check), use probeRequest
 // instead — it bypasses the breaker so the credentials check can still verify
 // recovery after the breaker has tripped.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (resp *http.Response, err error) {
 	logger := log.FromContext(ctx)
 
-	if c.config.APIKey == "" {
-		return nil, errors.New(errNoCredentials)
+	if err := c.config.credentialsError(); err != nil {
+		return nil, err
 	}
 
-	key := breakerKey(c.config.BaseURL, c.config.APIKey)
+	key := breakerKey(c.config.BaseURL, c.config.credentialKey())
 	if err := authBreaker.Allow(key, false); err != nil {
 		logger.V(1).Info("AuthBreaker: rejecting call", "key", key, "cooldown_remaining", authBreaker.CooldownRemaining(key).String())
+		// The breaker only opens on auth failures, so the reconcile backs
+		// off as for one.
+		noteUpstreamOutcome(ctx, errors.Wrap(ErrAuth, err.Error()))
 		return nil, err
 	}
+	defer func() { noteUpstreamOutcome(ctx, err) }()
 
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, path)
-
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to marshal request body")
 		}
-		bodyReader = bytes.NewReader(jsonBody)
-		logger.V(1).Info("Request body", "body", string(jsonBody))
+		logger.V(1).Info("Request body", "body", RedactJSON(jsonBody))
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create request")
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("SIGNOZ-API-KEY", c.config.APIKey)
-
-	logger.V(1).Info("Making request", "method", method, "url", url)
+	logger.V(1).Info("Making request", "method", method, "url", RedactURL(c.config.BaseURL)+redactAPIPath(path))
 
-	resp, err := c.httpClient.Do(req)
+	resp, err = c.send(ctx, method, path, jsonBody)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute request")
+		// A rejected login is an auth failure like a rejected request.
+		if errors.Is(err, ErrAuth) {
+			authBreaker.Record(key, true)
+		}
+		return nil, err
 	}
 
 	// Record outcome on the auth breaker before any classification so the
@@ -332,30 +463,66 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 	authBreaker.Record(key, authFailure)
 
 	if resp.StatusCode >= 400 {
+		respBody, header := resp.Body, resp.Header
 		defer func() {
-			if err := resp.Body.Close(); err != nil {
+			if err := respBody.Close(); err != nil {
 				// Ignore close error in error path
 				_ = err
 			}
 		}()
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		body := string(bodyBytes)
-		switch {
-		case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
-			return nil, errors.Wrapf(ErrAuth, "%s - %s", resp.Status, body)
-		case resp.StatusCode == http.StatusTooManyRequests:
-			ra := parseRetryAfter(resp.Header.Get("Retry-After"))
-			return nil, &RateLimitedError{RetryAfter: ra, Body: fmt.Sprintf("%s - %s", resp.Status, body)}
-		case resp.StatusCode >= 500:
-			return nil, errors.Wrapf(ErrTransient, "%s - %s", resp.Status, body)
-		default:
-			return nil, fmt.Errorf("API error: %s - %s", resp.Status, body)
+		bodyBytes, _ := io.ReadAll(respBody)
+		apiErr := newAPIError(method, path, resp.StatusCode, bodyBytes)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			// Every caller for the endpoint pauses until the deadline.
+			ra := parseRetryAfter(header.Get("Retry-After"))
+			pauseEndpoint(c.config.BaseURL, ra)
+			return nil, &RateLimitedError{RetryAfter: ra, Body: apiErr.Message, Err: apiErr}
 		}
+		// 401/403 and 5xx responses match ErrAuth and ErrTransient
+		// through APIError.Is.
+		return nil, apiErr
 	}
 
 	return resp, nil
 }
 
+// send makes a request with the configured headers and credentials. A 401
+// is retried once with other credentials where there are any: a new
+// session in Password mode, or the other API key of the credentials.
+// Only the outcome of the retry is returned, so the breaker sees an auth
+// failure only if both attempts were rejected.
+func (c *Client) send(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	if c.config.AuthMode == v1beta1.AuthModePassword {
+		return c.sendWithSession(ctx, method, path, body)
+	}
+	return c.sendWithAPIKey(ctx, method, path, body)
+}
+
+// sendOnce makes a single request authenticated by the given header.
+func (c *Client) sendOnce(ctx context.Context, method, path string, body []byte, authHeader, authValue string) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.config.BaseURL+path, bodyReader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	// Extra headers are set first so they can't replace the credentials.
+	for name, value := range c.config.Headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(authHeader, authValue)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request")
+	}
+	return resp, nil
+}
+
 // probeRequest performs an HTTP request for ProviderConfig credentials check.
 // It bypasses the auth breaker (key=probe, true) so a recovery probe can
 // verify credentials even while the breaker is open.
@@ -365,26 +532,26 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 func (c *Client) probeRequest(ctx context.Context, method, path string) (*http.Response, error) {
 	logger := log.FromContext(ctx)
 
-	if c.config.APIKey == "" {
-		return nil, errors.New(errNoCredentials)
+	if err := c.config.credentialsError(); err != nil {
+		return nil, err
 	}
 
-	url := fmt.Sprintf("%s%s", c.config.BaseURL, path)
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create probe request")
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, errors.Wrap(err, "probe failed")
 	}
-	req.Header.Set("SIGNOZ-API-KEY", c.config.APIKey)
 
-	logger.V(1).Info("Probe request", "method", method, "url", url)
-	resp, err := c.httpClient.Do(req)
+	logger.V(1).Info("Probe request", "method", method, "url", RedactURL(c.config.BaseURL)+redactAPIPath(path))
+	key := breakerKey(c.config.BaseURL, c.config.credentialKey())
+	resp, err := c.send(ctx, method, path, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to execute probe request")
+		if errors.Is(err, ErrAuth) {
+			authBreaker.Record(key, true)
+		}
+		return nil, errors.Wrap(err, "probe failed")
 	}
 
 	// Probe outcomes update the breaker so a successful probe closes the
 	// breaker; a failing probe re-arms it.
-	key := breakerKey(c.config.BaseURL, c.config.APIKey)
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
 		authBreaker.Record(key, true)
 	} else if resp.StatusCode >= 200 && resp.StatusCode < 400 {
@@ -393,12 +560,15 @@ func (c *Client) probeRequest(ctx context.Context, method, path string) (*http.R
 	return resp, nil
 }
 
+// probePath is the endpoint ProbeCredentials reads.
+const probePath = "/api/v1/channels?limit=1"
+
 // ProbeCredentials verifies that the configured credentials are accepted by
 // the upstream Signoz API. It performs a cheap, side-effect-free GET against
 // the channels endpoint and returns nil on a 2xx response, a typed error
 // otherwise. The result is fed back to the breaker.
 func (c *Client) ProbeCredentials(ctx context.Context) error {
-	resp, err := c.probeRequest(ctx, http.MethodGet, "/api/v1/channels?limit=1")
+	resp, err := c.probeRequest(ctx, http.MethodGet, probePath)
 	if err != nil {
 		return err
 	}
@@ -409,15 +579,13 @@ func (c *Client) ProbeCredentials(ctx context.Context) error {
 		return nil
 	}
 	bodyBytes, _ := io.ReadAll(resp.Body)
-	body := string(bodyBytes)
-	switch {
-	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
-		return errors.Wrapf(ErrAuth, "%s - %s", resp.Status, body)
-	case resp.StatusCode >= 500:
-		return errors.Wrapf(ErrTransient, "%s - %s", resp.Status, body)
-	default:
-		return fmt.Errorf("probe failed: %s - %s", resp.Status, body)
+	apiErr := newAPIError(http.MethodGet, probePath, resp.StatusCode, bodyBytes)
+	if resp.StatusCode == http.StatusTooManyRequests {
+		ra := parseRetryAfter(resp.Header.Get("Retry-After"))
+		pauseEndpoint(c.config.BaseURL, ra)
+		return errors.Wrap(&RateLimitedError{RetryAfter: ra, Body: apiErr.Message, Err: apiErr}, "probe failed")
 	}
+	return errors.Wrap(apiErr, "probe failed")
 }
 
 // parseResponse parses the response body into the given interface
@@ -443,7 +611,7 @@ func parseResponse(resp *http.Response, v interface{}) error {
 	}
 
 	if err := json.Unmarshal(bodyBytes, v); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal response: %s", string(bodyBytes))
+		return errors.Wrapf(err, "failed to unmarshal response: %s", RedactString(string(bodyBytes)))
 	}
 
 	return nil
@@ -820,6 +988,193 @@ func (c *Client) ListRules(ctx context.Context) ([]*RuleData, error) {
 	return result.Data, nil
 }
 
+// Rule state history API methods
+
+// RuleStateHistoryQuery is the request body shared by the rule history
+// endpoints (POST /api/v1/rules/{id}/history/...). Start and End are unix
+// milliseconds; Order is "asc" or "desc".
+type RuleStateHistoryQuery struct {
+	Start int64  `json:"start"`
+	End   int64  `json:"end"`
+	State string `json:"state,omitempty"`
+	Limit int64  `json:"limit,omitempty"`
+	Order string `json:"order,omitempty"`
+}
+
+// RuleStateHistory is a single state transition of one series of a rule, as
+// returned by the timeline endpoint. OverallState is the state of the rule
+// as a whole after this transition; State is the state of the series
+// identified by Fingerprint.
+type RuleStateHistory struct {
+	RuleID              string            `json:"ruleID"`
+	RuleName            string            `json:"ruleName"`
+	OverallState        string            `json:"overallState"`
+	OverallStateChanged bool              `json:"overallStateChanged"`
+	State               string            `json:"state"`
+	StateChanged        bool              `json:"stateChanged"`
+	UnixMilli           int64             `json:"unixMilli"`
+	Labels              RuleHistoryLabels `json:"labels"`
+	Fingerprint         uint64            `json:"fingerprint"`
+	Value               float64           `json:"value"`
+}
+
+// RuleHistoryLabels is the label set of a series in the rule history. The
+// history store keeps labels as a JSON-encoded string; depending on the
+// SigNoz release the API returns either that string verbatim or the decoded
+// object, so both forms are accepted.
+type RuleHistoryLabels map[string]string
+
+// UnmarshalJSON accepts a JSON object or a JSON string holding an object.
+func (l *RuleHistoryLabels) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			*l = nil
+			return nil
+		}
+		b = []byte(s)
+	}
+	var m map[string]string
+	if err := json.Unmarshal(b, &m); err != nil {
+		return err
+	}
+	*l = m
+	return nil
+}
+
+// RuleStateTimeline is the data payload of the timeline endpoint.
+type RuleStateTimeline struct {
+	Items []RuleStateHistory `json:"items"`
+	Total uint64             `json:"total"`
+}
+
+// RuleStateTimelineResponse wraps the timeline endpoint response.
+type RuleStateTimelineResponse struct {
+	Status string             `json:"status"`
+	Data   *RuleStateTimeline `json:"data"`
+}
+
+// RuleStatePeriod is one contiguous period of a rule's overall state, as
+// returned by the overall_status endpoint. Start and End are unix
+// milliseconds.
+type RuleStatePeriod struct {
+	State string `json:"state"`
+	Start int64  `json:"start"`
+	End   int64  `json:"end"`
+}
+
+// RuleOverallStatusResponse wraps the overall_status endpoint response.
+type RuleOverallStatusResponse struct {
+	Status string            `json:"status"`
+	Data   []RuleStatePeriod `json:"data"`
+}
+
+// GetRuleStateTimeline retrieves the per-series state transitions of a rule
+// within the queried time range.
+func (c *Client) GetRuleStateTimeline(ctx context.Context, id string, query *RuleStateHistoryQuery) (*RuleStateTimeline, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/rules/%s/history/timeline", id), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result RuleStateTimelineResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Data == nil {
+		return &RuleStateTimeline{}, nil
+	}
+	return result.Data, nil
+}
+
+// GetRuleOverallStatus retrieves the periods of a rule's overall state
+// within the queried time range, oldest first.
+func (c *Client) GetRuleOverallStatus(ctx context.Context, id string, query *RuleStateHistoryQuery) ([]RuleStatePeriod, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/api/v1/rules/%s/history/overall_status", id), query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result RuleOverallStatusResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// Query API methods
+
+const errNoQueryData = "query returned no data"
+
+// QueryRangeRequest is a v5 query_range request. Start and End are unix
+// milliseconds; CompositeQuery holds the query envelopes under "queries".
+type QueryRangeRequest struct {
+	Start          int64                  `json:"start"`
+	End            int64                  `json:"end"`
+	RequestType    string                 `json:"requestType"`
+	CompositeQuery map[string]interface{} `json:"compositeQuery"`
+}
+
+// ScalarColumn describes one column of a scalar query result.
+type ScalarColumn struct {
+	Name       string `json:"name"`
+	QueryName  string `json:"queryName"`
+	ColumnType string `json:"columnType"`
+}
+
+// ScalarResult is the result of one query of a scalar request: one row per
+// series, with group columns followed by aggregation columns.
+type ScalarResult struct {
+	QueryName string          `json:"queryName,omitempty"`
+	Columns   []ScalarColumn  `json:"columns"`
+	Data      [][]interface{} `json:"data"`
+}
+
+// ScalarResponse wraps the query_range response of a scalar request.
+type ScalarResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Type string `json:"type"`
+		Data struct {
+			Results []ScalarResult `json:"results"`
+		} `json:"data"`
+	} `json:"data"`
+}
+
+// QueryScalar runs a scalar query over the request's time range and returns
+// the first aggregation value the named query produced.
+func (c *Client) QueryScalar(ctx context.Context, req *QueryRangeRequest, queryName string) (float64, error) {
+	req.RequestType = "scalar"
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/v5/query_range", req)
+	if err != nil {
+		return 0, err
+	}
+
+	var result ScalarResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return 0, err
+	}
+
+	for _, r := range result.Data.Data.Results {
+		for i, col := range r.Columns {
+			if col.ColumnType != "aggregation" || (col.QueryName != queryName && r.QueryName != queryName) {
+				continue
+			}
+			if len(r.Data) == 0 || len(r.Data[0]) <= i {
+				return 0, errors.New(errNoQueryData)
+			}
+			v, ok := r.Data[0][i].(float64)
+			if !ok {
+				return 0, errors.Errorf("%s: %v is not a number", errNoQueryData, r.Data[0][i])
+			}
+			return v, nil
+		}
+	}
+	return 0, errors.New(errNoQueryData)
+}
+
 // NotificationChannel API methods
 
 // ChannelData represents a notification channel in SigNoz
@@ -923,10 +1278,138 @@ func (c *Client) TestChannel(ctx context.Context, channelData *ChannelData) erro
 	return err
 }
 
-// IsNotFound returns true if the error indicates a resource was not found
-func IsNotFound(err error) bool {
-	if err == nil {
-		return false
+// Planned maintenance API methods
+
+const errFindCreated = "cannot find created downtime schedule"
+
+// PlannedMaintenanceData represents a downtime schedule in SigNoz
+type PlannedMaintenanceData struct {
+	ID          string                      `json:"id,omitempty"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Schedule    *PlannedMaintenanceSchedule `json:"schedule"`
+	AlertIDs    []string                    `json:"alertIds"`
+	Status      string                      `json:"status,omitempty"`
+	Kind        string                      `json:"kind,omitempty"`
+	CreatedAt   string                      `json:"createdAt,omitempty"`
+	UpdatedAt   string                      `json:"updatedAt,omitempty"`
+}
+
+// PlannedMaintenanceSchedule is when a downtime schedule is in effect.
+// Times are RFC 3339; SigNoz keeps their wall-clock time in Timezone.
+type PlannedMaintenanceSchedule struct {
+	Timezone   string                        `json:"timezone"`
+	StartTime  string                        `json:"startTime,omitempty"`
+	EndTime    string                        `json:"endTime,omitempty"`
+	Recurrence *PlannedMaintenanceRecurrence `json:"recurrence,omitempty"`
+}
+
+// PlannedMaintenanceRecurrence repeats a downtime schedule
+type PlannedMaintenanceRecurrence struct {
+	StartTime  string   `json:"startTime"`
+	EndTime    string   `json:"endTime,omitempty"`
+	Duration   string   `json:"duration"`
+	RepeatType string   `json:"repeatType"`
+	RepeatOn   []string `json:"repeatOn,omitempty"`
+}
+
+// PlannedMaintenanceResponse wraps planned maintenance API responses
+type PlannedMaintenanceResponse struct {
+	Status string                  `json:"status"`
+	Data   *PlannedMaintenanceData `json:"data"`
+}
+
+// ListPlannedMaintenancesResponse wraps list planned maintenances response
+type ListPlannedMaintenancesResponse struct {
+	Status string                    `json:"status"`
+	Data   []*PlannedMaintenanceData `json:"data"`
+}
+
+// CreatePlannedMaintenance creates a new downtime schedule. SigNoz doesn't
+// return the created schedule, so it is looked up by its whole payload:
+// names aren't unique, and if more than one schedule matches there is no
+// telling which one was just created.
+func (c *Client) CreatePlannedMaintenance(ctx context.Context, m *PlannedMaintenanceData) (*PlannedMaintenanceData, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/api/v1/downtime_schedules", m)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status string          `json:"status"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	var created PlannedMaintenanceData
+	if json.Unmarshal(result.Data, &created) == nil && created.ID != "" {
+		return &created, nil
+	}
+
+	existing, err := c.ListPlannedMaintenances(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errFindCreated)
 	}
-	return strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found")
+	var found []*PlannedMaintenanceData
+	for _, e := range existing {
+		if PlannedMaintenanceDiff(m, e).Empty() {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, errors.Errorf("%s: no downtime schedule named %q matches", errFindCreated, m.Name)
+	case 1:
+		return found[0], nil
+	default:
+		return nil, errors.Errorf("%s: %d downtime schedules named %q match", errFindCreated, len(found), m.Name)
+	}
+}
+
+// GetPlannedMaintenance retrieves a downtime schedule by ID
+func (c *Client) GetPlannedMaintenance(ctx context.Context, id string) (*PlannedMaintenanceData, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/api/v1/downtime_schedules/%s", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PlannedMaintenanceResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// UpdatePlannedMaintenance updates an existing downtime schedule
+func (c *Client) UpdatePlannedMaintenance(ctx context.Context, id string, m *PlannedMaintenanceData) error {
+	resp, err := c.doRequest(ctx, http.MethodPut, fmt.Sprintf("/api/v1/downtime_schedules/%s", id), m)
+	if err != nil {
+		return err
+	}
+	// SigNoz doesn't return the updated schedule.
+	return parseResponse(resp, nil)
+}
+
+// DeletePlannedMaintenance deletes a downtime schedule
+func (c *Client) DeletePlannedMaintenance(ctx context.Context, id string) error {
+	_, err := c.doRequest(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/downtime_schedules/%s", id), nil)
+	return err
+}
+
+// ListPlannedMaintenances lists all downtime schedules
+func (c *Client) ListPlannedMaintenances(ctx context.Context) ([]*PlannedMaintenanceData, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/api/v1/downtime_schedules", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListPlannedMaintenancesResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
 }
@@ -385,6 +385,105 @@ func TestClient_CreateChannel(t *testing.T) {
 	}
 }
 
+// SigNoz returns no data from creating a downtime schedule, so the client
+// finds it by name, schedule and alerts.
+func TestClient_CreatePlannedMaintenance(t *testing.T) {
+	var posted PlannedMaintenanceData
+	schedule := &PlannedMaintenanceSchedule{Timezone: "UTC", StartTime: "2025-07-01T22:00:00Z", EndTime: "2025-07-02T01:00:00Z"}
+	listed := []*PlannedMaintenanceData{
+		{ID: "1", Name: "other", Schedule: schedule},
+		{ID: "2", Name: "db-upgrade", Status: "upcoming", Kind: "fixed", Schedule: schedule},
+		{ID: "3", Name: "db-upgrade", Schedule: &PlannedMaintenanceSchedule{Timezone: "UTC", StartTime: "2025-06-01T22:00:00Z"}},
+		{ID: "4", Name: "db-upgrade", Schedule: schedule, AlertIDs: []string{"a"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/downtime_schedules" {
+			t.Errorf("Expected path /api/v1/downtime_schedules, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Errorf("cannot decode request: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"status":"success","data":null}`))
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(ListPlannedMaintenancesResponse{
+				Status: "success",
+				Data:   listed,
+			})
+		default:
+			t.Errorf("unexpected %s", r.Method)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, APIKey: "test-key"})
+	m := &PlannedMaintenanceData{
+		Name:     "db-upgrade",
+		Schedule: &PlannedMaintenanceSchedule{Timezone: "UTC", StartTime: "2025-07-01T23:00:00+01:00", EndTime: "2025-07-02T01:00:00Z"},
+		AlertIDs: []string{},
+	}
+	created, err := client.CreatePlannedMaintenance(context.Background(), m)
+	if err != nil {
+		t.Fatalf("CreatePlannedMaintenance failed: %v", err)
+	}
+	if created.ID != "2" || created.Kind != "fixed" {
+		t.Errorf("Expected schedule 2 of kind fixed, got %s of kind %s", created.ID, created.Kind)
+	}
+	if posted.Schedule == nil || posted.Schedule.Timezone != "UTC" || posted.AlertIDs == nil {
+		t.Errorf("Unexpected request body: %+v", posted)
+	}
+
+	// Two identical schedules can't be told apart, so neither is adopted.
+	listed = append(listed, &PlannedMaintenanceData{ID: "5", Name: "db-upgrade", Schedule: schedule})
+	if _, err := client.CreatePlannedMaintenance(context.Background(), m); err == nil || !strings.Contains(err.Error(), "2 downtime schedules") {
+		t.Errorf("Expected an error naming 2 matching schedules, got %v", err)
+	}
+	listed = listed[:2]
+	m.AlertIDs = []string{"b"}
+	if _, err := client.CreatePlannedMaintenance(context.Background(), m); err == nil || !strings.Contains(err.Error(), errFindCreated) {
+		t.Errorf("Expected %q, got %v", errFindCreated, err)
+	}
+}
+
+func TestClient_PlannedMaintenanceByID(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/downtime_schedules/2" {
+			t.Errorf("Expected path /api/v1/downtime_schedules/2, got %s", r.URL.Path)
+		}
+		methods = append(methods, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"id":"2","name":"db-upgrade","alertIds":["a"],
+				"schedule":{"timezone":"UTC","startTime":"2025-07-01T22:00:00Z","recurrence":{"startTime":"2025-07-01T22:00:00Z","duration":"2h0m0s","repeatType":"daily"}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, APIKey: "test-key"})
+	ctx := context.Background()
+	got, err := client.GetPlannedMaintenance(ctx, "2")
+	if err != nil {
+		t.Fatalf("GetPlannedMaintenance failed: %v", err)
+	}
+	if got.Schedule == nil || got.Schedule.Recurrence == nil || got.Schedule.Recurrence.Duration != "2h0m0s" || len(got.AlertIDs) != 1 {
+		t.Errorf("Unexpected schedule: %+v", got)
+	}
+	if err := client.UpdatePlannedMaintenance(ctx, "2", got); err != nil {
+		t.Fatalf("UpdatePlannedMaintenance failed: %v", err)
+	}
+	if err := client.DeletePlannedMaintenance(ctx, "2"); err != nil {
+		t.Fatalf("DeletePlannedMaintenance failed: %v", err)
+	}
+	if strings.Join(methods, ",") != "GET,PUT,DELETE" {
+		t.Errorf("Expected GET, PUT and DELETE, got %v", methods)
+	}
+}
+
 func TestClient_APIError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusBadRequest)
@@ -407,6 +506,14 @@ func TestClient_APIError(t *testing.T) {
 	if !contains(err.Error(), "API error") {
 		t.Errorf("Expected API error, got %v", err)
 	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Path != "/api/v2/dashboards/non-existent" {
+		t.Errorf("Expected an APIError for the 400 response, got %#v", err)
+	}
+	if !IsValidation(err) || IsNotFound(err) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
 }
 
 func TestIsNotFound(t *testing.T) {
@@ -422,17 +529,22 @@ func TestIsNotFound(t *testing.T) {
 		},
 		{
 			name:     "404 error",
-			err:      errors.New("API error: 404 Not Found"),
+			err:      &APIError{StatusCode: http.StatusNotFound, Method: http.MethodGet, Path: "/api/v1/rules/1"},
 			expected: true,
 		},
 		{
-			name:     "not found error",
-			err:      errors.New("resource not found"),
+			name:     "wrapped not_found error type",
+			err:      errors.Wrap(&APIError{StatusCode: http.StatusInternalServerError, ErrorType: ErrorTypeNotFound}, "cannot get alert"),
 			expected: true,
 		},
 		{
-			name:     "other error",
-			err:      errors.New("API error: 500 Internal Server Error"),
+			name:     "validation error mentioning not found",
+			err:      &APIError{StatusCode: http.StatusBadRequest, ErrorType: ErrorTypeBadData, Message: `rule "service-not-found" is invalid`},
+			expected: false,
+		},
+		{
+			name:     "untyped error mentioning 404",
+			err:      errors.New("API error: 404 Not Found"),
 			expected: false,
 		},
 	}
@@ -446,3 +558,129 @@ func TestIsNotFound(t *testing.T) {
 		})
 	}
 }
+
+func TestIsConflictAndIsValidation(t *testing.T) {
+	conflict := &APIError{StatusCode: http.StatusConflict}
+	exists := &APIError{StatusCode: http.StatusBadRequest, ErrorType: ErrorTypeAlreadyExists}
+	invalid := &APIError{StatusCode: http.StatusBadRequest, ErrorType: ErrorTypeBadData}
+	unprocessable := &APIError{StatusCode: http.StatusUnprocessableEntity}
+
+	if !IsConflict(conflict) || !IsConflict(errors.Wrap(exists, "cannot create")) || IsConflict(invalid) {
+		t.Error("IsConflict() misclassified an error")
+	}
+	if !IsValidation(invalid) || !IsValidation(unprocessable) || IsValidation(conflict) || IsValidation(errors.New("bad_data")) {
+		t.Error("IsValidation() misclassified an error")
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantErrorType string
+		wantMessage   string
+	}{
+		{
+			name:          "v1 envelope",
+			body:          `{"status":"error","errorType":"not_found","error":"rule not found"}`,
+			wantErrorType: ErrorTypeNotFound,
+			wantMessage:   "rule not found",
+		},
+		{
+			name:          "structured error",
+			body:          `{"status":"error","error":{"code":"invalid_input","message":"name is required"}}`,
+			wantErrorType: ErrorTypeInvalidInput,
+			wantMessage:   "name is required",
+		},
+		{
+			name:        "plain body",
+			body:        "  upstream connect error\n",
+			wantMessage: "upstream connect error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newAPIError(http.MethodPut, "/api/v1/rules/1", http.StatusBadRequest, []byte(tt.body))
+			if e.ErrorType != tt.wantErrorType || e.Message != tt.wantMessage {
+				t.Errorf("newAPIError() type, message = %q, %q, want %q, %q", e.ErrorType, e.Message, tt.wantErrorType, tt.wantMessage)
+			}
+			if e.Method != http.MethodPut || e.Path != "/api/v1/rules/1" || e.StatusCode != http.StatusBadRequest {
+				t.Errorf("newAPIError() request = %s %s %d", e.Method, e.Path, e.StatusCode)
+			}
+		})
+	}
+}
+
+func TestClient_GetRuleStateTimeline(t *testing.T) {
+	var query RuleStateHistoryQuery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/rules/rule-123/history/timeline" {
+			t.Errorf("Expected timeline path, got %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&query)
+
+		// Labels arrive as an object on newer releases and as a
+		// JSON-encoded string on older ones; both must decode.
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"total":2,"items":[
+			{"ruleID":"rule-123","state":"firing","overallState":"firing","overallStateChanged":true,"unixMilli":2000,"fingerprint":7,"labels":{"service":"api"}},
+			{"ruleID":"rule-123","state":"inactive","overallState":"inactive","unixMilli":1000,"fingerprint":8,"labels":"{\"service\":\"web\"}"}
+		]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, APIKey: "test-key"})
+
+	timeline, err := client.GetRuleStateTimeline(context.Background(), "rule-123", &RuleStateHistoryQuery{Start: 1, End: 2, Order: "desc"})
+	if err != nil {
+		t.Fatalf("GetRuleStateTimeline failed: %v", err)
+	}
+	if query.Order != "desc" || query.End != 2 {
+		t.Errorf("query not sent on the wire, got %+v", query)
+	}
+	if len(timeline.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(timeline.Items))
+	}
+	if timeline.Items[0].Labels["service"] != "api" {
+		t.Errorf("Expected object labels to decode, got %v", timeline.Items[0].Labels)
+	}
+	if timeline.Items[1].Labels["service"] != "web" {
+		t.Errorf("Expected string-encoded labels to decode, got %v", timeline.Items[1].Labels)
+	}
+}
+
+func TestClient_QueryScalar(t *testing.T) {
+	var req QueryRangeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/query_range" {
+			t.Errorf("Expected query_range path, got %s", r.URL.Path)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"type":"scalar","data":{"results":[
+			{"columns":[{"name":"A","queryName":"A","columnType":"aggregation"}],"data":[[0.5]]},
+			{"columns":[{"name":"service","queryName":"F1","columnType":"group"},{"name":"F1","queryName":"F1","columnType":"aggregation"}],"data":[["api",0.002]]}
+		]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{BaseURL: server.URL, APIKey: "test-key"})
+
+	v, err := client.QueryScalar(context.Background(), &QueryRangeRequest{Start: 1, End: 2, CompositeQuery: map[string]interface{}{"queries": []interface{}{}}}, "F1")
+	if err != nil {
+		t.Fatalf("QueryScalar failed: %v", err)
+	}
+	if v != 0.002 {
+		t.Errorf("Expected the F1 aggregation, got %v", v)
+	}
+	if req.RequestType != "scalar" || req.End != 2 {
+		t.Errorf("request not sent on the wire, got %+v", req)
+	}
+
+	if _, err := client.QueryScalar(context.Background(), &QueryRangeRequest{}, "B"); err == nil {
+		t.Error("Expected an error for a query without data")
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+const (
+	errGetCABundle      = "cannot get CA bundle"
+	errNoCACertificates = "CA bundle contains no PEM encoded certificates"
+	errGetClientCert    = "cannot get client certificate Secret"
+	errLoadClientCert   = "cannot load client certificate"
+	errMinTLSVersion    = "unsupported minimum TLS version"
+)
+
+// ErrCertificate matches errors caused by TLS certificates: an invalid CA
+// bundle or client certificate in the ProviderConfig, or a SigNoz API
+// certificate or client certificate rejected during the handshake. Test
+// for it with IsCertificateError.
+var ErrCertificate = errors.New("signoz API: certificate error")
+
+// TLSOptions are the TLS settings of a Config, read from the ProviderConfig
+// and the Secrets and ConfigMaps it references.
+type TLSOptions struct {
+	// CAData is a PEM encoded CA bundle trusted in addition to the system
+	// roots.
+	CAData []byte
+
+	// CertData and KeyData are the PEM encoded client certificate and key
+	// presented for mutual TLS.
+	CertData []byte
+	KeyData  []byte
+
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS12.
+	MinVersion uint16
+
+	// ServerName overrides the name used for SNI and verification.
+	ServerName string
+}
+
+// fingerprint identifies the options without revealing the client key.
+func (o TLSOptions) fingerprint() string {
+	h := sha256.New()
+	for _, b := range [][]byte{o.CAData, o.CertData, o.KeyData, []byte(o.ServerName)} {
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+	fmt.Fprintf(h, "%d", o.MinVersion)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// tlsConfig builds the tls.Config for cfg.
+func tlsConfig(cfg Config) (*tls.Config, error) {
+	o := cfg.TLS
+	tc := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipTLSVerify, //nolint:gosec // opt-in via ProviderConfig
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.ServerName,
+	}
+	if o.MinVersion != 0 {
+		tc.MinVersion = o.MinVersion
+	}
+	if len(o.CAData) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(o.CAData) {
+			return nil, errors.Wrap(ErrCertificate, errNoCACertificates)
+		}
+		tc.RootCAs = pool
+	}
+	if len(o.CertData) > 0 || len(o.KeyData) > 0 {
+		cert, err := tls.X509KeyPair(o.CertData, o.KeyData)
+		if err != nil {
+			return nil, errors.Wrapf(ErrCertificate, "%s: %v", errLoadClientCert, err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	return tc, nil
+}
+
+// getTLSOptions reads the TLS settings of a ProviderConfig, resolving its
+// CA bundle and client certificate references. The result is validated, so
+// a bad bundle or key pair fails here rather than on the first request.
+func getTLSOptions(ctx context.Context, c client.Client, spec *v1beta1.TLSConfig) (TLSOptions, error) {
+	o := TLSOptions{}
+	if spec == nil {
+		return o, nil
+	}
+	o.ServerName = spec.ServerName
+
+	switch spec.MinVersion {
+	case "", "1.2":
+		o.MinVersion = tls.VersionTLS12
+	case "1.3":
+		o.MinVersion = tls.VersionTLS13
+	default:
+		return o, errors.Errorf("%s: %q", errMinTLSVersion, spec.MinVersion)
+	}
+
+	o.CAData = []byte(spec.CABundle)
+	if ref := spec.CABundleSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return o, errors.Wrap(err, errGetCABundle)
+		}
+		o.CAData = append(o.CAData, '\n')
+		o.CAData = append(o.CAData, s.Data[ref.Key]...)
+	}
+	if ref := spec.CABundleConfigMapRef; ref != nil {
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+			return o, errors.Wrap(err, errGetCABundle)
+		}
+		o.CAData = append(o.CAData, '\n')
+		o.CAData = append(o.CAData, cm.Data[ref.Key]...)
+	}
+
+	if ref := spec.ClientCertificateSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return o, errors.Wrap(err, errGetClientCert)
+		}
+		o.CertData = s.Data[corev1.TLSCertKey]
+		o.KeyData = s.Data[corev1.TLSPrivateKeyKey]
+		if len(o.CertData) == 0 || len(o.KeyData) == 0 {
+			return o, errors.Wrapf(ErrCertificate, "%s: Secret %s/%s has no %s and %s",
+				errLoadClientCert, ref.Namespace, ref.Name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+		}
+	}
+
+	if _, err := tlsConfig(Config{TLS: o}); err != nil {
+		return o, err
+	}
+	return o, nil
+}
+
+// IsCertificateError returns true if err was caused by a TLS certificate:
+// an invalid CA bundle or client certificate, a SigNoz API certificate that
+// couldn't be verified, or a client certificate the SigNoz API rejected.
+func IsCertificateError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		verifyErr     *tls.CertificateVerificationError
+		unknownAuth   x509.UnknownAuthorityError
+		invalidCert   x509.CertificateInvalidError
+		hostnameErr   x509.HostnameError
+		alertErr      tls.AlertError
+		systemRoots   x509.SystemRootsError
+		constraintErr x509.ConstraintViolationError
+	)
+	switch {
+	case stderrors.Is(err, ErrCertificate),
+		stderrors.As(err, &verifyErr),
+		stderrors.As(err, &unknownAuth),
+		stderrors.As(err, &invalidCert),
+		stderrors.As(err, &hostnameErr),
+		stderrors.As(err, &systemRoots),
+		stderrors.As(err, &constraintErr):
+		return true
+	case stderrors.As(err, &alertErr):
+		return isCertificateAlert(alertErr)
+	}
+	return false
+}
+
+// isCertificateAlert reports whether a TLS alert received from the SigNoz
+// API rejects the client's certificate: bad_certificate through
+// unknown_ca, or certificate_required.
+func isCertificateAlert(a tls.AlertError) bool {
+	return (a >= 42 && a <= 48) || a == 116
+}
+
+// transportFailure is the RoundTripper of a Client whose TLS settings are
+// invalid. It fails every request with the reason.
+type transportFailure struct {
+	err error
+}
+
+func (t transportFailure) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
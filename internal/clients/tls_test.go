@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/rossigee/provider-signoz/apis/v1beta1"
+)
+
+// serverCA returns the PEM encoded certificate of an httptest TLS server.
+func serverCA(ts *httptest.Server) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+}
+
+// clientKeyPair returns a self-signed client certificate and key.
+func clientKeyPair(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "provider-signoz"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestGetTLSOptions(t *testing.T) {
+	certPEM, keyPEM := clientKeyPair(t)
+	kube := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "crossplane-system"}, Data: map[string][]byte{"ca.crt": certPEM}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "ca", Namespace: "crossplane-system"}, Data: map[string]string{"bundle.pem": string(certPEM)}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mtls", Namespace: "crossplane-system"}, Data: map[string][]byte{
+			corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM,
+		}},
+	).Build()
+
+	o, err := getTLSOptions(context.Background(), kube, &v1beta1.TLSConfig{
+		CABundleSecretRef:          &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "crossplane-system"}, Key: "ca.crt"},
+		CABundleConfigMapRef:       &v1beta1.ConfigMapKeySelector{Name: "ca", Namespace: "crossplane-system", Key: "bundle.pem"},
+		ClientCertificateSecretRef: &xpv1.SecretReference{Name: "mtls", Namespace: "crossplane-system"},
+		MinVersion:                 "1.3",
+		ServerName:                 "signoz.internal",
+	})
+	if err != nil {
+		t.Fatalf("getTLSOptions() error = %v", err)
+	}
+	if o.MinVersion != tls.VersionTLS13 || o.ServerName != "signoz.internal" || len(o.CertData) == 0 || len(o.KeyData) == 0 {
+		t.Errorf("getTLSOptions() = %+v", o)
+	}
+
+	// A bundle without certificates is a certificate error, reported before
+	// any request is made.
+	_, err = getTLSOptions(context.Background(), kube, &v1beta1.TLSConfig{CABundle: "not a certificate"})
+	if !IsCertificateError(err) {
+		t.Errorf("getTLSOptions() error = %v, want a certificate error", err)
+	}
+	_, err = getTLSOptions(context.Background(), kube, &v1beta1.TLSConfig{
+		ClientCertificateSecretRef: &xpv1.SecretReference{Name: "ca", Namespace: "crossplane-system"},
+	})
+	if !IsCertificateError(err) {
+		t.Errorf("getTLSOptions() error = %v, want a certificate error", err)
+	}
+}
+
+func TestClient_CustomCA(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	cfg := Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"}
+
+	// The server's certificate isn't signed by a system root.
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); !IsCertificateError(err) {
+		t.Errorf("ProbeCredentials() error = %v, want a certificate error", err)
+	}
+
+	cfg.TLS = TLSOptions{CAData: serverCA(ts)}
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); err != nil {
+		t.Errorf("ProbeCredentials() with the CA bundle error = %v", err)
+	}
+
+	// The certificate is issued for example.com, not the SNI override.
+	cfg.TLS.ServerName = "signoz.internal"
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); !IsCertificateError(err) {
+		t.Errorf("ProbeCredentials() with a mismatched server name error = %v, want a certificate error", err)
+	}
+	cfg.TLS.ServerName = "example.com"
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); err != nil {
+		t.Errorf("ProbeCredentials() with server name example.com error = %v", err)
+	}
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	certPEM, keyPEM := clientKeyPair(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	ts.StartTLS()
+	defer ts.Close()
+
+	cfg := Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890", TLS: TLSOptions{CAData: serverCA(ts)}}
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); err == nil {
+		t.Error("ProbeCredentials() without a client certificate succeeded")
+	}
+
+	cfg.TLS.CertData, cfg.TLS.KeyData = certPEM, keyPEM
+	if err := NewClient(cfg).ProbeCredentials(context.Background()); err != nil {
+		t.Errorf("ProbeCredentials() with a client certificate error = %v", err)
+	}
+}
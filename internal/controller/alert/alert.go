@@ -19,6 +19,7 @@ package alert
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
@@ -39,7 +41,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -58,17 +63,20 @@ const (
 // Setup adds a controller that reconciles Alert managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.Alert_GroupVersionKind.Kind)
+	recorder := clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
 
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
 			kube:         resource.ClientApplicator{Client: mgr.GetClient(), Applicator: resource.NewAPIPatchingApplicator(mgr.GetClient())},
 			usage:        resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-			newServiceFn: clients.NewClient,
+			newServiceFn: clients.CachedClient,
+			recorder:     recorder,
+			pollInterval: o.PollInterval,
 		}),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features != nil && o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -83,9 +91,15 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
-		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1beta1.Alert{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&v1beta1.Alert{}, builder.WithPredicates(resource.DesiredStateChanged())).
+		// Re-queue Alerts as soon as a channel they reference becomes
+		// ready or is renamed, rather than waiting for the next poll.
+		// This can't go through WithEventFilter: readiness is a status
+		// change, which DesiredStateChanged deliberately ignores.
+		Watches(&channelv1beta1.NotificationChannel{},
+			handler.EnqueueRequestsFromMapFunc(alertsForChannel(mgr.GetClient())),
+			builder.WithPredicates(channelReferenceChanged())).
+		Complete(ratelimiter.NewReconciler(name, clients.NewBackoffReconciler(r, clients.DefaultBackoffPolicy), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -94,6 +108,8 @@ type connector struct {
 	kube         resource.ClientApplicator
 	usage        resource.ModernTracker
 	newServiceFn func(cfg clients.Config) *clients.Client
+	recorder     event.Recorder
+	pollInterval time.Duration
 }
 
 // Connect typically produces an ExternalClient by:
@@ -126,16 +142,20 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	return &external{
-		service: c.newServiceFn(*cfg),
-		kube:    c.kube.Client,
+		service:      c.newServiceFn(*cfg),
+		kube:         c.kube.Client,
+		recorder:     c.recorder,
+		pollInterval: c.pollInterval,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *clients.Client
-	kube    client.Client
+	service      *clients.Client
+	kube         client.Client
+	recorder     event.Recorder
+	pollInterval time.Duration
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -186,7 +206,6 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	// Update the status with observed values
 	cr.Status.AtProvider.ID = alert.ID
-	cr.Status.AtProvider.State = alert.State
 
 	if alert.CreatedAt != "" {
 		if createdAt, err := time.Parse(time.RFC3339, alert.CreatedAt); err == nil {
@@ -203,13 +222,55 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	// Set Ready condition since the resource exists
 	cr.Status.SetConditions(xpv1.Available())
 
-	// Resolve channel references and update status
-	if err := c.resolveChannelReferences(ctx, cr); err != nil {
+	// An Alert being deleted only needs its rule deleted. Its channels may
+	// be gone already, so they aren't resolved: an error now would stop the
+	// managed reconciler before it deletes the rule.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	// Record the live state, fired/resolved times and firing series from
+	// the rule's state history.
+	c.observeRuleHistory(ctx, cr, alertID, alert.State, time.Now())
+
+	// Resolve channel references and update status. A selector that
+	// matches no channel is reported on ReferencesResolved and the rule is
+	// left as-is; only Create and Update fail on it.
+	pending, err := c.resolveChannelReferences(ctx, cr)
+	if errors.Is(err, errNoChannelsMatched) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errResolveRefs)
 	}
 
-	// Check if the alert is up to date
-	upToDate := isAlertUpToDate(cr.Spec.ForProvider, alert)
+	// Check if the alert is up to date. While referenced channels are
+	// pending the rule is left as-is: an Update now would drop their
+	// routing, and the channel watch re-queues us once they are ready.
+	if err := validateCondition(cr.Spec.ForProvider.Condition); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	upToDate := len(pending) > 0
+	if !upToDate {
+		desired, err := buildRuleData(cr, c.service.Capabilities())
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		check := clients.DriftCheck{
+			SpecHash: clients.SpecHash(cr.Spec.ForProvider),
+			Payload:  desired,
+			Observed: alert,
+			Policy:   cr.Spec.ExternalChangePolicy,
+			Diff:     func() clients.Diff { return alertDiff(desired, alert) },
+		}
+		var drift *apisv1beta1.DriftSummary
+		if upToDate, drift, err = check.UpToDate(ctx, c.kube, c.recorder, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if drift != nil {
+			cr.Status.AtProvider.LastDrift = drift
+		}
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
@@ -224,11 +285,21 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	// Resolve channel references
-	if err := c.resolveChannelReferences(ctx, cr); err != nil {
+	pending, err := c.resolveChannelReferences(ctx, cr)
+	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errResolveRefs)
 	}
+	if len(pending) > 0 {
+		return managed.ExternalCreation{}, errors.Errorf("%s: %s", errReferencesPending, strings.Join(pending, ", "))
+	}
+	if err := validateCondition(cr.Spec.ForProvider.Condition); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
-	ruleData := buildRuleData(cr)
+	ruleData, err := buildRuleData(cr, c.service.Capabilities())
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
 	created, err := c.service.CreateRule(ctx, ruleData)
 	if err != nil {
@@ -258,19 +329,34 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	// Resolve channel references
-	if err := c.resolveChannelReferences(ctx, cr); err != nil {
+	pending, err := c.resolveChannelReferences(ctx, cr)
+	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errResolveRefs)
 	}
+	if len(pending) > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf("%s: %s", errReferencesPending, strings.Join(pending, ", "))
+	}
+	if err := validateCondition(cr.Spec.ForProvider.Condition); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	ruleData := buildRuleData(cr)
+	ruleData, err := buildRuleData(cr, c.service.Capabilities())
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	_, err := c.service.UpdateRule(ctx, alertID, ruleData)
+	_, err = c.service.UpdateRule(ctx, alertID, ruleData)
 	if err != nil {
 		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateAlert)
 	}
 	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
 
+	if err := clients.RecordApplied(ctx, c.kube, cr, clients.SpecHash(cr.Spec.ForProvider)); err != nil {
+		// The rule was updated; a missed count only delays loop detection.
+		log.FromContext(ctx).Info("Cannot record applied spec", "error", err)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -288,23 +374,35 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 // fix (changing it would need to correctly discriminate promql_rule/
 // threshold_rule/anomaly_rule from the condition shape, which only
 // exercises the flat-condition and Thresholds cases seen so far).
-func buildRuleData(cr *v1beta1.Alert) *clients.RuleData {
+//
+// The rule envelope version and schemaVersion are those the server's
+// capabilities call for. Servers too old for v5 envelopes, or for
+// thresholds, are rejected.
+func buildRuleData(cr *v1beta1.Alert, caps clients.Capabilities) (*clients.RuleData, error) {
+	version, err := caps.RuleVersion()
+	if err != nil {
+		return nil, err
+	}
 	ruleData := &clients.RuleData{
 		AlertName:         cr.Spec.ForProvider.AlertName,
 		AlertType:         convertAlertType(cr.Spec.ForProvider.AlertType),
 		RuleType:          "threshold_rule",
 		EvalWindow:        cr.Spec.ForProvider.EvalWindow,
 		Frequency:         cr.Spec.ForProvider.Frequency,
-		Condition:         convertCondition(cr.Spec.ForProvider.Condition),
+		Condition:         convertCondition(effectiveCondition(cr)),
 		Labels:            cr.Spec.ForProvider.Labels,
 		Annotations:       cr.Spec.ForProvider.Annotations,
 		PreferredChannels: cr.Status.AtProvider.ResolvedChannelIDs,
 		Disabled:          cr.Spec.ForProvider.Disabled,
 		Severity:          cr.Spec.ForProvider.Severity,
-		Version:           "v5",
+		Version:           version,
 	}
 
 	if len(cr.Spec.ForProvider.Condition.Thresholds) > 0 {
+		schemaVersion, err := caps.ThresholdSchemaVersion()
+		if err != nil {
+			return nil, err
+		}
 		ruleData.Evaluation = &clients.RuleEvaluation{
 			Kind: "rolling",
 			Spec: clients.RuleEvaluationSpec{
@@ -312,14 +410,14 @@ func buildRuleData(cr *v1beta1.Alert) *clients.RuleData {
 				Frequency:  cr.Spec.ForProvider.Frequency,
 			},
 		}
-		ruleData.SchemaVersion = "v2alpha1"
+		ruleData.SchemaVersion = schemaVersion
 		ruleData.NotificationSettings = &clients.RuleNotificationSettings{
 			Renotify:  clients.RuleRenotify{Enabled: false, Interval: "30m"},
 			UsePolicy: false,
 		}
 	}
 
-	return ruleData
+	return ruleData, nil
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
@@ -350,81 +448,225 @@ func (c *external) Disconnect(ctx context.Context) error {
 
 // Helper functions
 
-func isAlertUpToDate(spec v1beta1.AlertParameters, alert *clients.RuleData) bool {
-	if spec.AlertName != alert.AlertName {
-		return false
+// alertDiff compares the payload buildRuleData would send against the rule
+// returned by SigNoz, field by field, and returns the fields that differ.
+// Fields the server fills in on its own (the evaluation block and
+// notification settings of a flat-condition rule, schemaVersion, ruleType)
+// are only compared when we send them.
+func alertDiff(desired, observed *clients.RuleData) clients.Diff {
+	var d clients.Diff
+
+	if desired.AlertName != observed.AlertName {
+		d.Add("alert", desired.AlertName, observed.AlertName)
 	}
 
-	if convertAlertType(spec.AlertType) != alert.AlertType {
-		return false
+	if desired.AlertType != observed.AlertType {
+		d.Add("alertType", desired.AlertType, observed.AlertType)
 	}
 
-	if spec.EvalWindow != alert.EvalWindow {
-		return false
+	if !durationEqual(desired.EvalWindow, observed.EvalWindow) {
+		d.Add("evalWindow", desired.EvalWindow, observed.EvalWindow)
 	}
 
-	if spec.Frequency != alert.Frequency {
-		return false
+	if !durationEqual(desired.Frequency, observed.Frequency) {
+		d.Add("frequency", desired.Frequency, observed.Frequency)
 	}
 
-	if spec.Disabled != alert.Disabled {
-		return false
+	if desired.Disabled != observed.Disabled {
+		d.Add("disabled", desired.Disabled, observed.Disabled)
 	}
 
-	// Compare labels
-	if !mapsEqual(spec.Labels, alert.Labels) {
-		return false
+	diffStringMaps(&d, "labels", desired.Labels, observed.Labels)
+	diffStringMaps(&d, "annotations", desired.Annotations, observed.Annotations)
+
+	if !severityEqual(desired.Severity, observed) {
+		d.Add("severity", desired.Severity, observed.Severity)
 	}
 
-	// Compare annotations
-	if !mapsEqual(spec.Annotations, alert.Annotations) {
-		return false
+	// Routing is a set: SigNoz doesn't promise to keep the order we sent.
+	if !clients.StringSetsEqual(desired.PreferredChannels, observed.PreferredChannels) {
+		d.Add("preferredChannels", desired.PreferredChannels, observed.PreferredChannels)
 	}
 
+	if desired.RuleType != "" && observed.RuleType != "" && desired.RuleType != observed.RuleType {
+		d.Add("ruleType", desired.RuleType, observed.RuleType)
+	}
+
+	if desired.SchemaVersion != "" && desired.SchemaVersion != observed.SchemaVersion {
+		d.Add("schemaVersion", desired.SchemaVersion, observed.SchemaVersion)
+	}
+
+	diffEvaluation(&d, desired.Evaluation, observed.Evaluation)
+	diffNotificationSettings(&d, desired.NotificationSettings, observed.NotificationSettings)
+
 	// Compare the rendered condition against the live condition. This is
 	// what catches a builder_query schema mismatch (e.g. provider vs SigNoz
 	// v5) - if we always claimed "up to date" the user would never see
 	// drift in the form of repeated PUT attempts.
-	desiredCondition := convertCondition(spec.Condition)
-	return conditionEqual(desiredCondition, alert.Condition)
+	diffCondition(&d, "condition", desired.Condition, observed.Condition)
+
+	return d
+}
+
+// durationEqual compares two duration strings by value, since GET responses
+// return the canonical long form ("5m0s") of what we sent ("5m"). Strings
+// that don't parse as durations are compared verbatim.
+func durationEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	ad, aerr := time.ParseDuration(a)
+	bd, berr := time.ParseDuration(b)
+	return aerr == nil && berr == nil && ad == bd
+}
+
+// severityEqual compares the desired severity with the rule's. Some SigNoz
+// versions only return it as the "severity" label rather than a top-level
+// field.
+func severityEqual(desired string, observed *clients.RuleData) bool {
+	if observed.Severity != "" || desired == "" {
+		return desired == observed.Severity
+	}
+	return desired == observed.Labels["severity"]
+}
+
+// diffEvaluation compares the evaluation block. A nil desired block means
+// we don't send one (flat-condition rules), in which case whatever SigNoz
+// derives from evalWindow/frequency is accepted.
+func diffEvaluation(d *clients.Diff, desired, observed *clients.RuleEvaluation) {
+	if desired == nil {
+		return
+	}
+	if observed == nil {
+		d.Add("evaluation", desired, nil)
+		return
+	}
+	if desired.Kind != observed.Kind {
+		d.Add("evaluation.kind", desired.Kind, observed.Kind)
+	}
+	if !durationEqual(desired.Spec.EvalWindow, observed.Spec.EvalWindow) {
+		d.Add("evaluation.spec.evalWindow", desired.Spec.EvalWindow, observed.Spec.EvalWindow)
+	}
+	if !durationEqual(desired.Spec.Frequency, observed.Spec.Frequency) {
+		d.Add("evaluation.spec.frequency", desired.Spec.Frequency, observed.Spec.Frequency)
+	}
+}
+
+// diffNotificationSettings compares notification settings, with the same
+// nil-desired semantics as diffEvaluation.
+func diffNotificationSettings(d *clients.Diff, desired, observed *clients.RuleNotificationSettings) {
+	if desired == nil {
+		return
+	}
+	if observed == nil {
+		d.Add("notificationSettings", desired, nil)
+		return
+	}
+	if desired.UsePolicy != observed.UsePolicy {
+		d.Add("notificationSettings.usePolicy", desired.UsePolicy, observed.UsePolicy)
+	}
+	if desired.Renotify.Enabled != observed.Renotify.Enabled {
+		d.Add("notificationSettings.renotify.enabled", desired.Renotify.Enabled, observed.Renotify.Enabled)
+	}
+	if !durationEqual(desired.Renotify.Interval, observed.Renotify.Interval) {
+		d.Add("notificationSettings.renotify.interval", desired.Renotify.Interval, observed.Renotify.Interval)
+	}
+}
+
+// diffStringMaps records each key of a label-style map whose value differs,
+// treating nil and empty maps alike.
+func diffStringMaps(d *clients.Diff, path string, desired, observed map[string]string) {
+	for _, k := range sortedKeys(desired) {
+		ov, ok := observed[k]
+		if !ok {
+			d.Add(clients.JoinPath(path, k), desired[k], nil)
+		} else if ov != desired[k] {
+			d.Add(clients.JoinPath(path, k), desired[k], ov)
+		}
+	}
+	for _, k := range sortedKeys(observed) {
+		if _, ok := desired[k]; !ok {
+			d.Add(clients.JoinPath(path, k), nil, observed[k])
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // conditionEqual compares two condition maps while tolerating known
 // fields that SigNoz may normalise (int vs string for op/matchType,
 // nil vs absent for unit/legend, etc).
 func conditionEqual(desired, observed map[string]interface{}) bool {
+	var d clients.Diff
+	diffCondition(&d, "", desired, observed)
+	return d.Empty()
+}
+
+// diffCondition records the fields of two condition maps that differ, with
+// the same tolerances as conditionEqual.
+func diffCondition(d *clients.Diff, path string, desired, observed map[string]interface{}) {
 	if desired == nil && observed == nil {
-		return true
+		return
+	}
+	if desired == nil {
+		d.Add(path, nil, observed)
+		return
 	}
-	if desired == nil || observed == nil {
-		return false
+	if observed == nil {
+		d.Add(path, desired, nil)
+		return
 	}
 
-	for k, dv := range desired {
+	for _, k := range sortedKeys(desired) {
+		dv := desired[k]
 		ov, ok := observed[k]
 		if !ok {
 			// Tolerate empty/zero values that SigNoz may strip.
-			if isZeroish(dv) {
-				continue
+			if !isZeroish(dv) {
+				d.Add(clients.JoinPath(path, k), dv, nil)
 			}
-			return false
-		}
-		if !valueEqual(dv, ov) {
-			return false
+			continue
 		}
+		diffValue(d, clients.JoinPath(path, k), dv, ov)
 	}
 
-	for k, ov := range observed {
+	for _, k := range sortedKeys(observed) {
 		if _, ok := desired[k]; ok {
 			continue
 		}
-		if isZeroish(ov) {
-			continue
+		if ov := observed[k]; !isZeroish(ov) {
+			d.Add(clients.JoinPath(path, k), nil, ov)
 		}
-		return false
 	}
+}
 
-	return true
+// diffValue descends into maps and equal-length slices so the diff names
+// the innermost differing field, and otherwise compares with valueEqual.
+func diffValue(d *clients.Diff, path string, desired, observed interface{}) {
+	if dm, ok := desired.(map[string]interface{}); ok {
+		if om, ok := observed.(map[string]interface{}); ok {
+			diffCondition(d, path, dm, om)
+			return
+		}
+	}
+	if ds, ok := desired.([]interface{}); ok {
+		if os, ok := observed.([]interface{}); ok && len(ds) == len(os) {
+			for i := range ds {
+				diffValue(d, clients.IndexPath(path, i), ds[i], os[i])
+			}
+			return
+		}
+	}
+	if !valueEqual(desired, observed) {
+		d.Add(path, desired, observed)
+	}
 }
 
 func valueEqual(a, b interface{}) bool {
@@ -539,18 +781,6 @@ func isZeroish(v interface{}) bool {
 	return false
 }
 
-func mapsEqual(a, b map[string]string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for k, v := range a {
-		if b[k] != v {
-			return false
-		}
-	}
-	return true
-}
-
 // convertAlertType translates the CRD's alertType enum to the value
 // SigNoz's rules API actually expects on the wire. SigNoz's naming is
 // inconsistent across signal types - METRIC_BASED_ALERT is accepted as-is
@@ -572,7 +802,7 @@ func convertAlertType(alertType string) string {
 func convertCondition(condition v1beta1.RuleCondition) map[string]interface{} {
 	result := map[string]interface{}{
 		"compositeQuery":    convertCompositeQuery(condition.CompositeQuery),
-		"selectedQueryName": "A",
+		"selectedQueryName": selectedQueryName(condition),
 	}
 
 	// Multi-level thresholds replace the flat op/target/matchType entirely
@@ -654,7 +884,7 @@ func convertCompositeQuery(query v1beta1.CompositeQuery) map[string]interface{}
 			queryType = "promql"
 		} else if len(query.ClickHouse) > 0 {
 			queryType = "clickhouse_sql"
-		} else if query.Builder != nil {
+		} else if query.Builder != nil || len(query.Builders) > 0 {
 			queryType = "builder"
 		} else {
 			queryType = "builder"
@@ -677,14 +907,12 @@ func convertCompositeQuery(query v1beta1.CompositeQuery) map[string]interface{}
 	// NOT the legacy query-range v3 builderQueries/promQueries maps.
 	// SigNoz v0.137.1's ruletypes.AlertCompositeQuery.Queries is
 	// []qbtypes.QueryEnvelope (see pkg/types/ruletypes/alerting.go).
-	queries := make([]interface{}, 0, len(query.PromQL)+len(query.ClickHouse)+1)
+	builders := builderQueries(query)
+	queries := make([]interface{}, 0, len(query.PromQL)+len(query.ClickHouse)+len(builders)+len(query.Formulas))
 
 	if len(query.PromQL) > 0 {
 		for i, pq := range query.PromQL {
-			name := pq.Name
-			if name == "" {
-				name = fmt.Sprintf("A%d", i)
-			}
+			name := promQueryName(pq.Name, i)
 			queries = append(queries, map[string]interface{}{
 				"type": "promql",
 				"spec": map[string]interface{}{
@@ -700,10 +928,7 @@ func convertCompositeQuery(query v1beta1.CompositeQuery) map[string]interface{}
 
 	if len(query.ClickHouse) > 0 {
 		for i, chq := range query.ClickHouse {
-			name := chq.Name
-			if name == "" {
-				name = fmt.Sprintf("A%d", i)
-			}
+			name := promQueryName(chq.Name, i)
 			queries = append(queries, map[string]interface{}{
 				"type": "clickhouse_sql",
 				"spec": map[string]interface{}{
@@ -716,10 +941,18 @@ func convertCompositeQuery(query v1beta1.CompositeQuery) map[string]interface{}
 		}
 	}
 
-	if query.Builder != nil {
+	// Builder queries go before the formulas that reference them; the
+	// drift comparison is positional, so this order must stay stable.
+	for _, b := range builders {
 		queries = append(queries, map[string]interface{}{
 			"type": "builder_query",
-			"spec": convertQueryBuilder(*query.Builder),
+			"spec": convertQueryBuilder(b),
+		})
+	}
+	for _, f := range query.Formulas {
+		queries = append(queries, map[string]interface{}{
+			"type": "builder_formula",
+			"spec": convertFormula(f),
 		})
 	}
 
@@ -883,84 +1116,164 @@ func convertQueryBuilder(builder v1beta1.QueryBuilder) map[string]interface{} {
 	return spec
 }
 
+// convertFilterSet renders the structured filters as the v5 rules API's
+// {expression: string}. Filters are checked by validateFilters before any
+// payload is built, so a compile error can't reach here.
 func convertFilterSet(filterSet v1beta1.FilterSet) map[string]interface{} {
-	// The v5 rules API expects filter as {expression: string}. Build the
-	// expression from the structured items following SigNoz's filter
-	// syntax ("key op value", joined by AND/OR). Empty items yield an
-	// empty expression, which SigNoz treats as "no filter".
-	parts := make([]string, 0, len(filterSet.Items))
-	for _, item := range filterSet.Items {
-		if item.Key.Key == "" {
-			continue
-		}
-		if item.Value == nil || *item.Value == "" {
-			parts = append(parts, fmt.Sprintf("%s %s", item.Key.Key, item.Op))
-			continue
-		}
-		parts = append(parts, fmt.Sprintf("%s %s '%s'", item.Key.Key, item.Op, *item.Value))
-	}
-
-	expression := strings.Join(parts, " "+filterSet.Operator+" ")
+	expression, _ := compileFilterSet(filterSet)
 	return map[string]interface{}{
 		"expression": expression,
 	}
 }
 
-func (c *external) resolveChannelReferences(ctx context.Context, cr *v1beta1.Alert) error {
+// resolveChannelReferences resolves the Alert's alert-level and per-threshold
+// channel references into status, and returns the names of referenced
+// NotificationChannels that are not ready yet. The ReferencesResolved
+// condition is updated to match, including when a selector matches no
+// channel and errNoChannelsMatched is returned.
+func (c *external) resolveChannelReferences(ctx context.Context, cr *v1beta1.Alert) ([]string, error) {
 	var channelIDs []string
 
 	// Add preferred channels directly
 	channelIDs = append(channelIDs, cr.Spec.ForProvider.PreferredChannels...)
 
+	resolved, pending, err := c.resolveChannels(ctx, cr, cr.Spec.ForProvider.ChannelIDsRef, cr.Spec.ForProvider.ChannelIDsSelector)
+	if err != nil {
+		if errors.Is(err, errNoChannelsMatched) {
+			setNoChannelsCondition(cr, err)
+		}
+		return nil, err
+	}
+	channelIDs = append(channelIDs, resolved...)
+
+	// Remove duplicates and update status
+	uniqueChannelIDs := removeDuplicates(channelIDs)
+	cr.Status.AtProvider.ResolvedChannelIDs = uniqueChannelIDs
+
+	// Resolve per-threshold references the same way, so each severity
+	// level can route to its own channels.
+	var thresholdChannels []v1beta1.ThresholdChannels
+	for _, t := range cr.Spec.ForProvider.Condition.Thresholds {
+		if len(t.ChannelRefs) == 0 && t.ChannelSelector == nil {
+			continue
+		}
+		names, thresholdPending, err := c.resolveChannels(ctx, cr, t.ChannelRefs, t.ChannelSelector)
+		if err != nil {
+			err = errors.Wrapf(err, "cannot resolve channels for threshold %s", t.Name)
+			if errors.Is(err, errNoChannelsMatched) {
+				setNoChannelsCondition(cr, err)
+			}
+			return nil, err
+		}
+		pending = append(pending, thresholdPending...)
+		thresholdChannels = append(thresholdChannels, v1beta1.ThresholdChannels{
+			Name:     t.Name,
+			Channels: removeDuplicates(append(append([]string{}, t.Channels...), names...)),
+		})
+	}
+	cr.Status.AtProvider.ResolvedThresholdChannels = thresholdChannels
+
+	pending = removeDuplicates(pending)
+	setReferencesCondition(cr, pending)
+
+	return pending, nil
+}
+
+// resolveChannels returns the SigNoz channel names of the NotificationChannels
+// named by refs, in order, followed by those matched by selector, sorted by
+// Kubernetes name, along with the Kubernetes names of those that are not
+// ready yet. Pending channels are not included in the resolved names. A
+// selector that matches no channel at all is an error.
+func (c *external) resolveChannels(ctx context.Context, cr *v1beta1.Alert, refs []xpv1.Reference, selector *v1beta1.ChannelSelector) ([]string, []string, error) {
+	var channelIDs, pending []string
+	namespace := cr.GetNamespace()
+
 	// Resolve explicit references
-	for _, ref := range cr.Spec.ForProvider.ChannelIDsRef {
+	for _, ref := range refs {
 		if ref.Name != "" {
 			// Get the NotificationChannel resource
 			channel := &channelv1beta1.NotificationChannel{}
-			if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: ref.Name}, channel); err != nil {
-				return errors.Wrapf(err, "cannot get notification channel %s", ref.Name)
+			if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, channel); err != nil {
+				return nil, nil, errors.Wrapf(err, "cannot get notification channel %s", ref.Name)
 			}
-
-			// SigNoz rules API validates preferredChannels against the channel's
-			// display name, not its UUID.
-			if name := channel.Spec.ForProvider.Name; name != "" {
+			if !channelReady(channel) {
+				pending = append(pending, ref.Name)
+				continue
+			}
+			if name := signozChannelName(channel); name != "" {
 				channelIDs = append(channelIDs, name)
-			} else if channelID := channel.GetAnnotations()["crossplane.io/external-name"]; channelID != "" {
-				channelIDs = append(channelIDs, channelID)
 			}
 		}
 	}
 
 	// Resolve selector-based references
-	if cr.Spec.ForProvider.ChannelIDsSelector != nil {
-		selector := cr.Spec.ForProvider.ChannelIDsSelector
-		channelList := &channelv1beta1.NotificationChannelList{}
-
-		listOptions := []client.ListOption{
-			client.InNamespace(cr.GetNamespace()),
+	if selector != nil {
+		labelSel, err := labelSelector(selector)
+		if err != nil {
+			return nil, nil, err
 		}
-		if selector.MatchLabels != nil {
-			listOptions = append(listOptions, client.MatchingLabels(selector.MatchLabels))
+
+		channelList := &channelv1beta1.NotificationChannelList{}
+		if err := c.kube.List(ctx, channelList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSel}); err != nil {
+			return nil, nil, errors.Wrap(err, "cannot list notification channels")
 		}
 
-		if err := c.kube.List(ctx, channelList, listOptions...); err != nil {
-			return errors.Wrap(err, "cannot list notification channels")
+		// The API server doesn't guarantee list order; sort so the payload
+		// sent to SigNoz doesn't flap between reconciles.
+		selected := make([]*channelv1beta1.NotificationChannel, 0, len(channelList.Items))
+		for i := range channelList.Items {
+			if controllerMatches(cr, selector, &channelList.Items[i]) {
+				selected = append(selected, &channelList.Items[i])
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil, errors.Wrapf(errNoChannelsMatched, "namespace %s", namespace)
 		}
+		sort.Slice(selected, func(i, j int) bool { return selected[i].GetName() < selected[j].GetName() })
 
-		for _, channel := range channelList.Items {
-			if name := channel.Spec.ForProvider.Name; name != "" {
+		for _, channel := range selected {
+			if !channelReady(channel) {
+				pending = append(pending, channel.GetName())
+				continue
+			}
+			if name := signozChannelName(channel); name != "" {
 				channelIDs = append(channelIDs, name)
-			} else if channelID := channel.GetAnnotations()["crossplane.io/external-name"]; channelID != "" {
-				channelIDs = append(channelIDs, channelID)
 			}
 		}
 	}
 
-	// Remove duplicates and update status
-	uniqueChannelIDs := removeDuplicates(channelIDs)
-	cr.Status.AtProvider.ResolvedChannelIDs = uniqueChannelIDs
+	return channelIDs, pending, nil
+}
 
-	return nil
+// signozChannelName returns the name the SigNoz rules API knows the channel
+// by. preferredChannels is validated against the channel's display name, not
+// its UUID, so the external name is only a fallback.
+func signozChannelName(channel *channelv1beta1.NotificationChannel) string {
+	if name := channel.Spec.ForProvider.Name; name != "" {
+		return name
+	}
+	return meta.GetExternalName(channel)
+}
+
+// effectiveCondition returns the Alert's condition with each threshold's
+// Channels replaced by the names resolved into
+// status.atProvider.resolvedThresholdChannels, so the payload sent to
+// SigNoz and the drift comparison both see the routed channels.
+func effectiveCondition(cr *v1beta1.Alert) v1beta1.RuleCondition {
+	condition := *cr.Spec.ForProvider.Condition.DeepCopy()
+	if len(cr.Status.AtProvider.ResolvedThresholdChannels) == 0 {
+		return condition
+	}
+	resolved := make(map[string][]string, len(cr.Status.AtProvider.ResolvedThresholdChannels))
+	for _, tc := range cr.Status.AtProvider.ResolvedThresholdChannels {
+		resolved[tc.Name] = tc.Channels
+	}
+	for i := range condition.Thresholds {
+		if channels, ok := resolved[condition.Thresholds[i].Name]; ok {
+			condition.Thresholds[i].Channels = channels
+		}
+	}
+	return condition
 }
 
 func removeDuplicates(slice []string) []string {
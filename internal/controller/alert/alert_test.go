@@ -17,13 +17,26 @@ limitations under the License.
 package alert
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	channelv1beta1 "github.com/rossigee/provider-signoz/apis/channel/v1beta1"
 	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
-func TestIsAlertUpToDate(t *testing.T) {
+func TestAlertDiff(t *testing.T) {
 	spec := v1beta1.AlertParameters{
 		AlertName:   "Test Alert",
 		AlertType:   "METRIC_BASED_ALERT",
@@ -47,51 +60,33 @@ func TestIsAlertUpToDate(t *testing.T) {
 		// compositeQuery with builder query A).
 		Condition: convertCondition(spec.Condition),
 	}
+	desired := mustBuildRuleData(t, &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: spec}})
 
-	if !isAlertUpToDate(spec, alert) {
+	if !alertDiff(desired, alert).Empty() {
 		t.Error("Expected alert to be up to date")
 	}
 
 	// Test when alert name differs
 	alert.AlertName = "Different Name"
-	if isAlertUpToDate(spec, alert) {
+	if alertDiff(desired, alert).Empty() {
 		t.Error("Expected alert to not be up to date due to different name")
 	}
 	alert.AlertName = spec.AlertName // Reset
 
 	// Test when disabled differs
 	alert.Disabled = true
-	if isAlertUpToDate(spec, alert) {
+	if alertDiff(desired, alert).Empty() {
 		t.Error("Expected alert to not be up to date due to different disabled state")
 	}
 	alert.Disabled = spec.Disabled // Reset
 
 	// Test when labels differ
 	alert.Labels = map[string]string{"team": "frontend"}
-	if isAlertUpToDate(spec, alert) {
+	if alertDiff(desired, alert).Empty() {
 		t.Error("Expected alert to not be up to date due to different labels")
 	}
 }
 
-func TestMapsEqual(t *testing.T) {
-	a := map[string]string{"key1": "value1", "key2": "value2"}
-	b := map[string]string{"key1": "value1", "key2": "value2"}
-	c := map[string]string{"key1": "value1", "key2": "different"}
-	d := map[string]string{"key1": "value1", "key3": "value2"}
-
-	if !mapsEqual(a, b) {
-		t.Error("Expected maps a and b to be equal")
-	}
-
-	if mapsEqual(a, c) {
-		t.Error("Expected maps a and c to not be equal")
-	}
-
-	if mapsEqual(a, d) {
-		t.Error("Expected maps a and d to not be equal")
-	}
-}
-
 func TestRemoveDuplicates(t *testing.T) {
 	input := []string{"a", "b", "a", "c", "b"}
 	expected := []string{"a", "b", "c"}
@@ -434,6 +429,16 @@ func float64Ptr(f float64) *float64 {
 	return &f
 }
 
+// mustBuildRuleData builds the payload for a server of unknown version.
+func mustBuildRuleData(t *testing.T, cr *v1beta1.Alert) *clients.RuleData {
+	t.Helper()
+	rd, err := buildRuleData(cr, clients.Capabilities{})
+	if err != nil {
+		t.Fatalf("buildRuleData() error = %v", err)
+	}
+	return rd
+}
+
 func TestConvertQueryBuilder_FilterExpression(t *testing.T) {
 	builder := v1beta1.QueryBuilder{
 		QueryName:         "A",
@@ -730,11 +735,11 @@ func TestConvertAlertType(t *testing.T) {
 	}
 }
 
-// TestIsAlertUpToDate_LogAlertTypeTranslation guards against comparing the
+// TestAlertDiff_LogAlertTypeTranslation guards against comparing the
 // CRD's raw LOG_BASED_ALERT against the observed LOGS_BASED_ALERT
 // directly, which would report drift forever on an otherwise-correct log
 // alert and force a spurious Update on every reconcile.
-func TestIsAlertUpToDate_LogAlertTypeTranslation(t *testing.T) {
+func TestAlertDiff_LogAlertTypeTranslation(t *testing.T) {
 	spec := v1beta1.AlertParameters{
 		AlertName:  "Test Alert",
 		AlertType:  "LOG_BASED_ALERT",
@@ -748,8 +753,9 @@ func TestIsAlertUpToDate_LogAlertTypeTranslation(t *testing.T) {
 		Frequency:  "1m",
 		Condition:  convertCondition(spec.Condition),
 	}
+	desired := mustBuildRuleData(t, &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: spec}})
 
-	if !isAlertUpToDate(spec, alert) {
+	if !alertDiff(desired, alert).Empty() {
 		t.Error("expected LOG_BASED_ALERT spec to match observed LOGS_BASED_ALERT without drift")
 	}
 }
@@ -780,7 +786,7 @@ func TestBuildRuleData_EvaluationOnlyForThresholds(t *testing.T) {
 		},
 	}
 
-	rd := buildRuleData(withThresholds)
+	rd := mustBuildRuleData(t, withThresholds)
 	if rd.Evaluation == nil {
 		t.Error("expected Evaluation to be set for an alert using Thresholds")
 	}
@@ -805,7 +811,7 @@ func TestBuildRuleData_EvaluationOnlyForThresholds(t *testing.T) {
 		},
 	}
 
-	rd2 := buildRuleData(withoutThresholds)
+	rd2 := mustBuildRuleData(t, withoutThresholds)
 	if rd2.Evaluation != nil {
 		t.Error("expected Evaluation to be nil for a flat-condition alert (no Thresholds)")
 	}
@@ -816,3 +822,541 @@ func TestBuildRuleData_EvaluationOnlyForThresholds(t *testing.T) {
 		t.Error("expected NotificationSettings to be nil for a flat-condition alert (no Thresholds)")
 	}
 }
+
+func TestBuildRuleData_Capabilities(t *testing.T) {
+	flat := &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+		AlertName: "CPU",
+		Condition: v1beta1.RuleCondition{
+			CompositeQuery: v1beta1.CompositeQuery{PromQL: []v1beta1.AlertPromQuery{{Name: "A", Query: "cpu > 0.8"}}},
+			CompareOp:      ">",
+			Target:         float64Ptr(80),
+		},
+	}}}
+	thresholds := &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+		AlertName: "Errors",
+		Condition: v1beta1.RuleCondition{Thresholds: []v1beta1.Threshold{{Name: "critical", Op: "1", MatchType: "1"}}},
+	}}}
+
+	rd, err := buildRuleData(flat, clients.Capabilities{Version: "v0.92.1"})
+	if err != nil || rd.Version != "v5" {
+		t.Fatalf("buildRuleData() on v0.92.1 = %v, %v, want a v5 rule", rd, err)
+	}
+	cq, _ := rd.Condition["compositeQuery"].(map[string]interface{})
+	if _, ok := cq["queries"].([]interface{}); !ok {
+		t.Errorf("compositeQuery of a v5 rule = %v, want a queries list", cq)
+	}
+
+	// The v4 shape, with builderQueries maps, isn't built: servers that
+	// only take it are rejected rather than sent a v5 payload.
+	rd, err = buildRuleData(flat, clients.Capabilities{Version: "v0.80.0"})
+	if err == nil || rd != nil || !strings.Contains(err.Error(), "an alert requires SigNoz v0.85.0 or later, the server is v0.80.0") {
+		t.Errorf("buildRuleData() on v0.80.0 = %v, %v, want unsupported server", rd, err)
+	}
+	if _, err := buildRuleData(thresholds, clients.Capabilities{Version: "v0.70.0"}); err == nil || !strings.Contains(err.Error(), "requires SigNoz") {
+		t.Errorf("buildRuleData() with thresholds on v0.70.0 error = %v, want unsupported server", err)
+	}
+}
+
+func newFakeKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := channelv1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add channel types to scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+// notificationChannel returns a NotificationChannel that has been created
+// in SigNoz (Ready, with an external name).
+func notificationChannel(name, channelName string, labels map[string]string) *channelv1beta1.NotificationChannel {
+	ch := &channelv1beta1.NotificationChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "monitoring",
+			Labels:      labels,
+			Annotations: map[string]string{"crossplane.io/external-name": clients.GenerateExternalName("monitoring", name)},
+		},
+		Spec: channelv1beta1.NotificationChannelSpec{
+			ForProvider: channelv1beta1.NotificationChannelParameters{Name: channelName, Type: "slack"},
+		},
+	}
+	ch.Status.SetConditions(xpv1.Available())
+	return ch
+}
+
+// TestResolveChannelReferences_PerThreshold verifies that thresholds can
+// route to their own channels through refs and selectors, and that the
+// resolved names end up both in status and in the rendered condition.
+func TestResolveChannelReferences_PerThreshold(t *testing.T) {
+	kube := newFakeKube(t,
+		notificationChannel("slack-warnings", "Slack Warnings", map[string]string{"severity": "warning"}),
+		notificationChannel("pagerduty", "PagerDuty Oncall", map[string]string{"severity": "critical"}),
+	)
+	e := &external{kube: kube}
+
+	cr := &v1beta1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "latency", Namespace: "monitoring"},
+		Spec: v1beta1.AlertSpec{
+			ForProvider: v1beta1.AlertParameters{
+				Condition: v1beta1.RuleCondition{
+					Thresholds: []v1beta1.Threshold{
+						{
+							Name:            "warning",
+							Channels:        []string{"email-team"},
+							ChannelSelector: &v1beta1.ChannelSelector{MatchLabels: map[string]string{"severity": "warning"}},
+						},
+						{
+							Name:        "critical",
+							ChannelRefs: []xpv1.Reference{{Name: "pagerduty"}},
+						},
+						{
+							Name:     "info",
+							Channels: []string{"raw-only"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pending, err := e.resolveChannelReferences(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("resolveChannelReferences failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending channels, got %v", pending)
+	}
+
+	got := cr.Status.AtProvider.ResolvedThresholdChannels
+	if len(got) != 2 {
+		t.Fatalf("expected 2 thresholds with resolved channels (info uses raw names only), got %v", got)
+	}
+	if got[0].Name != "warning" || len(got[0].Channels) != 2 || got[0].Channels[0] != "email-team" || got[0].Channels[1] != "Slack Warnings" {
+		t.Errorf("unexpected warning channels: %+v", got[0])
+	}
+	if got[1].Name != "critical" || len(got[1].Channels) != 1 || got[1].Channels[0] != "PagerDuty Oncall" {
+		t.Errorf("unexpected critical channels: %+v", got[1])
+	}
+
+	thresholds := convertCondition(effectiveCondition(cr))["thresholds"].(map[string]interface{})["spec"].([]interface{})
+	critical := thresholds[1].(map[string]interface{})
+	if chans := critical["channels"].([]interface{}); len(chans) != 1 || chans[0] != "PagerDuty Oncall" {
+		t.Errorf("expected critical threshold to carry resolved channel on the wire, got %v", critical["channels"])
+	}
+	info := thresholds[2].(map[string]interface{})
+	if chans := info["channels"].([]interface{}); len(chans) != 1 || chans[0] != "raw-only" {
+		t.Errorf("expected info threshold to keep its raw channels, got %v", info["channels"])
+	}
+
+	if cr.Spec.ForProvider.Condition.Thresholds[1].Channels != nil {
+		t.Error("effectiveCondition must not mutate the spec")
+	}
+}
+
+// TestResolveChannelReferences_PendingChannels verifies that channels which
+// are not yet Ready in SigNoz are held back and reported on the
+// ReferencesResolved condition rather than sent to the rules API.
+func TestResolveChannelReferences_PendingChannels(t *testing.T) {
+	notReady := notificationChannel("slack-new", "Slack New", nil)
+	notReady.Status.SetConditions(xpv1.Creating())
+	noExternalName := notificationChannel("webhook-new", "Webhook New", map[string]string{"team": "sre"})
+	noExternalName.SetAnnotations(nil)
+
+	e := &external{kube: newFakeKube(t,
+		notificationChannel("pagerduty", "PagerDuty Oncall", nil),
+		notReady,
+		noExternalName,
+	)}
+
+	cr := &v1beta1.Alert{
+		ObjectMeta: metav1.ObjectMeta{Name: "latency", Namespace: "monitoring"},
+		Spec: v1beta1.AlertSpec{
+			ForProvider: v1beta1.AlertParameters{
+				ChannelIDsRef:      []xpv1.Reference{{Name: "pagerduty"}, {Name: "slack-new"}},
+				ChannelIDsSelector: &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "sre"}},
+			},
+		},
+	}
+
+	pending, err := e.resolveChannelReferences(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("resolveChannelReferences failed: %v", err)
+	}
+	if len(pending) != 2 || pending[0] != "slack-new" || pending[1] != "webhook-new" {
+		t.Errorf("expected slack-new and webhook-new pending, got %v", pending)
+	}
+	if got := cr.Status.AtProvider.ResolvedChannelIDs; len(got) != 1 || got[0] != "PagerDuty Oncall" {
+		t.Errorf("expected only the ready channel to resolve, got %v", got)
+	}
+
+	c := cr.Status.GetCondition(TypeReferencesResolved)
+	if c.Status != "False" || c.Reason != ReasonChannelsPending {
+		t.Errorf("expected ReferencesResolved=False/%s, got %s/%s", ReasonChannelsPending, c.Status, c.Reason)
+	}
+	if !strings.Contains(c.Message, "slack-new") || !strings.Contains(c.Message, "webhook-new") {
+		t.Errorf("expected condition message to list pending channels, got %q", c.Message)
+	}
+}
+
+// TestObserve_ChannelsGone verifies that an Alert whose channels were
+// deleted first is still observed, so it can be deleted, and that a
+// selector matching nothing is reported on ReferencesResolved.
+func TestObserve_ChannelsGone(t *testing.T) {
+	id := clients.GenerateExternalName("monitoring", "latency")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/rules/"+id {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"id":%q,"alert":"Latency"}}`, id)
+	}))
+	defer ts.Close()
+	e := &external{
+		kube:    newFakeKube(t),
+		service: clients.NewClient(clients.Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"}),
+	}
+	alert := func() *v1beta1.Alert {
+		cr := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "latency", Namespace: "monitoring"}}
+		cr.Spec.ForProvider.ChannelIDsSelector = &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "sre"}}
+		meta.SetExternalName(cr, id)
+		return cr
+	}
+
+	cr := alert()
+	cr.Spec.ForProvider.ChannelIDsRef = []xpv1.Reference{{Name: "deleted"}}
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	if obs, err := e.Observe(context.Background(), cr); err != nil || !obs.ResourceExists {
+		t.Errorf("Observe() of a deleted Alert = %+v, %v, want the rule to be deleted", obs, err)
+	}
+
+	cr = alert()
+	obs, err := e.Observe(context.Background(), cr)
+	if err != nil || !obs.ResourceExists || !obs.ResourceUpToDate {
+		t.Errorf("Observe() with no channels selected = %+v, %v, want the rule left as-is", obs, err)
+	}
+	if c := cr.Status.GetCondition(TypeReferencesResolved); c.Status != "False" || c.Reason != ReasonNoChannelsSelected {
+		t.Errorf("expected ReferencesResolved=False/%s, got %s/%s", ReasonNoChannelsSelected, c.Status, c.Reason)
+	}
+	if _, err := e.Update(context.Background(), cr); err == nil || !strings.Contains(err.Error(), errNoChannelsSelected) {
+		t.Errorf("Update() error = %v, want %q", err, errNoChannelsSelected)
+	}
+}
+
+// TestObserve_RuleHistory verifies that the rule's state history is queried
+// at most once per poll interval, and not at all for an Alert being deleted.
+func TestObserve_RuleHistory(t *testing.T) {
+	id := clients.GenerateExternalName("monitoring", "latency")
+	queries := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/rules/" + id:
+			_, _ = fmt.Fprintf(w, `{"status":"success","data":{"id":%q,"alert":"Latency","state":"inactive"}}`, id)
+		case "/api/v1/rules/" + id + "/history/overall_status":
+			queries++
+			_, _ = w.Write([]byte(`{"status":"success","data":[{"state":"firing","start":1,"end":2}]}`))
+		case "/api/v1/rules/" + id + "/history/timeline":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"items":[]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	e := &external{
+		kube:         newFakeKube(t),
+		service:      clients.NewClient(clients.Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"}),
+		pollInterval: time.Hour,
+	}
+	cr := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "latency", Namespace: "monitoring"}}
+	meta.SetExternalName(cr, id)
+
+	for i := 0; i < 2; i++ {
+		if _, err := e.Observe(context.Background(), cr); err != nil {
+			t.Fatalf("Observe() error = %v", err)
+		}
+	}
+	if queries != 1 {
+		t.Errorf("history queried %d times in one poll interval, want 1", queries)
+	}
+	if cr.Status.AtProvider.State != "firing" || cr.Status.AtProvider.HistoryObservedAt == nil {
+		t.Errorf("expected the state from the history to be kept, got %q at %v", cr.Status.AtProvider.State, cr.Status.AtProvider.HistoryObservedAt)
+	}
+
+	cr.Status.AtProvider.HistoryObservedAt = nil
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("Observe() of a deleted Alert error = %v", err)
+	}
+	if queries != 1 {
+		t.Errorf("history queried for a deleted Alert")
+	}
+}
+
+func TestAlertReferencesChannel(t *testing.T) {
+	channel := notificationChannel("pagerduty", "PagerDuty Oncall", map[string]string{"severity": "critical"})
+
+	byRef := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"}}
+	byRef.Spec.ForProvider.ChannelIDsRef = []xpv1.Reference{{Name: "pagerduty"}}
+
+	byThresholdSelector := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"}}
+	byThresholdSelector.Spec.ForProvider.Condition.Thresholds = []v1beta1.Threshold{
+		{Name: "critical", ChannelSelector: &v1beta1.ChannelSelector{MatchLabels: map[string]string{"severity": "critical"}}},
+	}
+
+	otherNamespace := byRef.DeepCopy()
+	otherNamespace.Namespace = "default"
+
+	unrelated := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"}}
+	unrelated.Spec.ForProvider.ChannelIDsRef = []xpv1.Reference{{Name: "slack"}}
+
+	for name, tc := range map[string]struct {
+		alert *v1beta1.Alert
+		want  bool
+	}{
+		"ByRef":               {alert: byRef, want: true},
+		"ByThresholdSelector": {alert: byThresholdSelector, want: true},
+		"OtherNamespace":      {alert: otherNamespace, want: false},
+		"Unrelated":           {alert: unrelated, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := alertReferencesChannel(tc.alert, channel); got != tc.want {
+				t.Errorf("alertReferencesChannel() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestResolveChannels_Selector covers the full ChannelSelector semantics:
+// set-based expressions, controller matching, ordering and the errors for
+// empty selectors and selectors that match nothing.
+func TestResolveChannels_Selector(t *testing.T) {
+	controller := metav1.OwnerReference{
+		APIVersion: "example.org/v1",
+		Kind:       "Team",
+		Name:       "sre",
+		UID:        "team-sre",
+		Controller: ptrTo(true),
+	}
+
+	zulu := notificationChannel("zulu", "Zulu", map[string]string{"team": "sre"})
+	zulu.SetOwnerReferences([]metav1.OwnerReference{controller})
+	alpha := notificationChannel("alpha", "Alpha", map[string]string{"team": "sre"})
+	alpha.SetOwnerReferences([]metav1.OwnerReference{controller})
+	mike := notificationChannel("mike", "Mike", map[string]string{"team": "platform"})
+	legacy := notificationChannel("legacy", "Legacy", map[string]string{"team": "sre", "deprecated": "true"})
+
+	e := &external{kube: newFakeKube(t, zulu, alpha, mike, legacy)}
+
+	owned := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{
+		Name:            "latency",
+		Namespace:       "monitoring",
+		OwnerReferences: []metav1.OwnerReference{controller},
+	}}
+	orphan := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "latency", Namespace: "monitoring"}}
+
+	for name, tc := range map[string]struct {
+		alert    *v1beta1.Alert
+		selector *v1beta1.ChannelSelector
+		want     []string
+		wantErr  string
+	}{
+		"MatchLabelsSorted": {
+			alert:    orphan,
+			selector: &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "sre"}},
+			want:     []string{"Alpha", "Legacy", "Zulu"},
+		},
+		"MatchExpressions": {
+			alert: orphan,
+			selector: &v1beta1.ChannelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"sre", "platform"}},
+				{Key: "deprecated", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			want: []string{"Alpha", "Mike", "Zulu"},
+		},
+		"MatchControllerRef": {
+			alert:    owned,
+			selector: &v1beta1.ChannelSelector{MatchControllerRef: ptrTo(true)},
+			want:     []string{"Alpha", "Zulu"},
+		},
+		"MatchControllerRefWithoutController": {
+			alert:    orphan,
+			selector: &v1beta1.ChannelSelector{MatchControllerRef: ptrTo(true)},
+			wantErr:  errNoChannelsSelected,
+		},
+		"Empty": {
+			alert:    orphan,
+			selector: &v1beta1.ChannelSelector{},
+			wantErr:  errEmptyChannelSelector,
+		},
+		"NoMatch": {
+			alert:    orphan,
+			selector: &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "nobody"}},
+			wantErr:  errNoChannelsSelected,
+		},
+		"InvalidExpression": {
+			alert: orphan,
+			selector: &v1beta1.ChannelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "team", Operator: metav1.LabelSelectorOpIn},
+			}},
+			wantErr: errInvalidChannelSel,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, _, err := e.resolveChannels(context.Background(), tc.alert, nil, tc.selector)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("resolveChannels() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveChannels() failed: %v", err)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("resolveChannels() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSelects_EmptySelectorMatchesNothing(t *testing.T) {
+	channel := notificationChannel("pagerduty", "PagerDuty Oncall", map[string]string{"severity": "critical"})
+	cr := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring"}}
+
+	if selects(cr, &v1beta1.ChannelSelector{}, channel) {
+		t.Error("expected an empty selector to match no channels")
+	}
+}
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+// TestAlertDiff_AllSentFields covers the fields beyond the condition
+// that buildRuleData sends, and the canonical forms SigNoz returns them in.
+func TestAlertDiff_AllSentFields(t *testing.T) {
+	cr := &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+		AlertName:  "Latency",
+		AlertType:  "METRIC_BASED_ALERT",
+		EvalWindow: "5m",
+		Frequency:  "1m",
+		Severity:   "critical",
+		Condition: v1beta1.RuleCondition{
+			Thresholds: []v1beta1.Threshold{{Name: "critical", Target: 1, MatchType: "1", Op: "1"}},
+		},
+	}}}
+	cr.Status.AtProvider.ResolvedChannelIDs = []string{"PagerDuty Oncall", "Slack Alerts"}
+	desired := mustBuildRuleData(t, cr)
+
+	// observed returns the rule as SigNoz echoes it back, with durations in
+	// their canonical long form.
+	observed := func() *clients.RuleData {
+		o := *desired
+		o.EvalWindow = "5m0s"
+		o.Frequency = "1m0s"
+		o.Evaluation = &clients.RuleEvaluation{Kind: "rolling", Spec: clients.RuleEvaluationSpec{EvalWindow: "5m0s", Frequency: "1m0s"}}
+		o.NotificationSettings = &clients.RuleNotificationSettings{Renotify: clients.RuleRenotify{Interval: "30m0s"}}
+		o.PreferredChannels = []string{"Slack Alerts", "PagerDuty Oncall"}
+		return &o
+	}
+
+	for name, tc := range map[string]struct {
+		mutate   func(*clients.RuleData)
+		wantPath string
+	}{
+		"CanonicalForms":          {mutate: func(*clients.RuleData) {}},
+		"SeverityChanged":         {mutate: func(o *clients.RuleData) { o.Severity = "warning" }, wantPath: "severity"},
+		"ChannelAdded":            {mutate: func(o *clients.RuleData) { o.PreferredChannels = append(o.PreferredChannels, "Email") }, wantPath: "preferredChannels"},
+		"ChannelRemoved":          {mutate: func(o *clients.RuleData) { o.PreferredChannels = o.PreferredChannels[:1] }, wantPath: "preferredChannels"},
+		"EvaluationMissing":       {mutate: func(o *clients.RuleData) { o.Evaluation = nil }, wantPath: "evaluation"},
+		"EvaluationWindowChanged": {mutate: func(o *clients.RuleData) { o.Evaluation.Spec.EvalWindow = "10m0s" }, wantPath: "evaluation.spec.evalWindow"},
+		"EvaluationKindChanged":   {mutate: func(o *clients.RuleData) { o.Evaluation.Kind = "cumulative" }, wantPath: "evaluation.kind"},
+		"RenotifyEnabled":         {mutate: func(o *clients.RuleData) { o.NotificationSettings.Renotify.Enabled = true }, wantPath: "notificationSettings.renotify.enabled"},
+		"RenotifyInterval":        {mutate: func(o *clients.RuleData) { o.NotificationSettings.Renotify.Interval = "1h0m0s" }, wantPath: "notificationSettings.renotify.interval"},
+		"UsePolicy":               {mutate: func(o *clients.RuleData) { o.NotificationSettings.UsePolicy = true }, wantPath: "notificationSettings.usePolicy"},
+		"NotificationsMissing":    {mutate: func(o *clients.RuleData) { o.NotificationSettings = nil }, wantPath: "notificationSettings"},
+		"SchemaVersionChanged":    {mutate: func(o *clients.RuleData) { o.SchemaVersion = "v1" }, wantPath: "schemaVersion"},
+		"FrequencyChanged":        {mutate: func(o *clients.RuleData) { o.Frequency = "2m0s" }, wantPath: "frequency"},
+		"LabelAdded":              {mutate: func(o *clients.RuleData) { o.Labels = map[string]string{"team": "web"} }, wantPath: "labels.team"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			o := observed()
+			tc.mutate(o)
+			diff := alertDiff(desired, o)
+			if tc.wantPath == "" {
+				if !diff.Empty() {
+					t.Errorf("alertDiff() = %s, want no drift", diff)
+				}
+				return
+			}
+			if len(diff) != 1 || diff[0].Path != tc.wantPath {
+				t.Errorf("alertDiff() = %s, want a single difference at %s", diff, tc.wantPath)
+			}
+		})
+	}
+
+	// Severity reported only as a label is accepted when the top-level
+	// field is absent.
+	o := observed()
+	o.Severity = ""
+	o.Labels = map[string]string{"severity": "critical"}
+	desiredWithLabel := *desired
+	desiredWithLabel.Labels = map[string]string{"severity": "critical"}
+	if !alertDiff(&desiredWithLabel, o).Empty() {
+		t.Error("expected severity reported as a label to match")
+	}
+}
+
+// TestAlertDiff_FlatConditionIgnoresServerEvaluation verifies that
+// the evaluation block SigNoz returns for a rule we sent without one is
+// not treated as drift.
+func TestAlertDiff_FlatConditionIgnoresServerEvaluation(t *testing.T) {
+	desired := mustBuildRuleData(t, &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+		AlertName:  "CPU",
+		EvalWindow: "5m",
+		Frequency:  "1m",
+		Condition:  v1beta1.RuleCondition{CompareOp: ">", Target: float64Ptr(80)},
+	}}})
+
+	observed := *desired
+	observed.SchemaVersion = "v1"
+	observed.Evaluation = &clients.RuleEvaluation{Kind: "rolling", Spec: clients.RuleEvaluationSpec{EvalWindow: "5m0s", Frequency: "1m0s"}}
+	observed.NotificationSettings = &clients.RuleNotificationSettings{Renotify: clients.RuleRenotify{Interval: "30m0s"}}
+
+	if !alertDiff(desired, &observed).Empty() {
+		t.Error("expected server-derived evaluation settings to be ignored for a flat-condition rule")
+	}
+}
+
+func TestBuildRuleData_ResolvedThresholdChannels(t *testing.T) {
+	cr := &v1beta1.Alert{Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+		Condition: v1beta1.RuleCondition{Thresholds: []v1beta1.Threshold{{Name: "critical", Op: "1", MatchType: "1"}}},
+	}}}
+	cr.Status.AtProvider.ResolvedThresholdChannels = []v1beta1.ThresholdChannels{{Name: "critical", Channels: []string{"PagerDuty Oncall"}}}
+
+	spec := mustBuildRuleData(t, cr).Condition["thresholds"].(map[string]interface{})["spec"].([]interface{})
+	channels := spec[0].(map[string]interface{})["channels"].([]interface{})
+	if len(channels) != 1 || channels[0] != "PagerDuty Oncall" {
+		t.Errorf("expected resolved threshold channels in the payload, got %v", channels)
+	}
+}
+
+func TestDurationEqual(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"5m", "5m0s", true},
+		{"1h", "60m", true},
+		{"90s", "1m30s", true},
+		{"5m", "6m0s", false},
+		{"", "", true},
+		{"", "0s", false},
+		{"weekly", "weekly", true},
+	} {
+		if got := durationEqual(tc.a, tc.b); got != tc.want {
+			t.Errorf("durationEqual(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+)
+
+const errInvalidFilter = "invalid filter"
+
+// maxFilterGroupDepth bounds how deep filter groups nest. The CRD schema
+// can't describe a recursive type, so the limit is enforced here.
+const maxFilterGroupDepth = 4
+
+// filterKeyPattern matches the keys accepted by the SigNoz v5 filter
+// grammar, including context prefixes (resource.service.name) and map or
+// array access (attributes[key]).
+var filterKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.:\[\]*-]*$`)
+
+// operatorArity describes the operands an operator takes.
+type operatorArity int
+
+const (
+	arityNone   operatorArity = iota // EXISTS
+	aritySingle                      // = 'x'
+	arityList                        // IN ('x', 'y')
+	arityRange                       // BETWEEN 1 AND 2
+)
+
+type filterOperator struct {
+	keyword string
+	arity   operatorArity
+
+	// textual operators always take a string operand, whatever the key's
+	// data type.
+	textual bool
+
+	// ordered operators compare numerically, so an untyped operand that
+	// parses as a number is written unquoted.
+	ordered bool
+}
+
+var filterOperators = map[string]filterOperator{
+	"=":            {keyword: "=", arity: aritySingle},
+	"!=":           {keyword: "!=", arity: aritySingle},
+	"<":            {keyword: "<", arity: aritySingle, ordered: true},
+	"<=":           {keyword: "<=", arity: aritySingle, ordered: true},
+	">":            {keyword: ">", arity: aritySingle, ordered: true},
+	">=":           {keyword: ">=", arity: aritySingle, ordered: true},
+	"LIKE":         {keyword: "LIKE", arity: aritySingle, textual: true},
+	"NOT LIKE":     {keyword: "NOT LIKE", arity: aritySingle, textual: true},
+	"ILIKE":        {keyword: "ILIKE", arity: aritySingle, textual: true},
+	"NOT ILIKE":    {keyword: "NOT ILIKE", arity: aritySingle, textual: true},
+	"CONTAINS":     {keyword: "CONTAINS", arity: aritySingle, textual: true},
+	"NOT CONTAINS": {keyword: "NOT CONTAINS", arity: aritySingle, textual: true},
+	"REGEXP":       {keyword: "REGEXP", arity: aritySingle, textual: true},
+	"NOT REGEXP":   {keyword: "NOT REGEXP", arity: aritySingle, textual: true},
+	"IN":           {keyword: "IN", arity: arityList},
+	"NOT IN":       {keyword: "NOT IN", arity: arityList},
+	"BETWEEN":      {keyword: "BETWEEN", arity: arityRange, ordered: true},
+	"NOT BETWEEN":  {keyword: "NOT BETWEEN", arity: arityRange, ordered: true},
+	"EXISTS":       {keyword: "EXISTS", arity: arityNone},
+	"NOT EXISTS":   {keyword: "NOT EXISTS", arity: arityNone},
+}
+
+// filterOperatorAliases maps the query builder's short operator names, and
+// a few common spellings, to their v5 keyword.
+var filterOperatorAliases = map[string]string{
+	"==":        "=",
+	"<>":        "!=",
+	"NIN":       "NOT IN",
+	"NLIKE":     "NOT LIKE",
+	"NILIKE":    "NOT ILIKE",
+	"NCONTAINS": "NOT CONTAINS",
+	"REGEX":     "REGEXP",
+	"NREGEX":    "NOT REGEXP",
+	"NEXISTS":   "NOT EXISTS",
+	"NBETWEEN":  "NOT BETWEEN",
+}
+
+// lookupFilterOperator resolves op case-insensitively, accepting "_" in
+// place of spaces (not_in) and the aliases above.
+func lookupFilterOperator(op string) (filterOperator, bool) {
+	name := strings.Join(strings.Fields(strings.ToUpper(strings.ReplaceAll(op, "_", " "))), " ")
+	if alias, ok := filterOperatorAliases[name]; ok {
+		name = alias
+	}
+	o, ok := filterOperators[name]
+	return o, ok
+}
+
+// compileFilterSet compiles a structured FilterSet into a SigNoz v5 filter
+// expression. Items are joined by the set's operator and nested groups are
+// parenthesised, so precedence never depends on SigNoz's AND/OR binding.
+// An empty set compiles to an empty expression, which SigNoz treats as "no
+// filter".
+func compileFilterSet(fs v1beta1.FilterSet) (string, error) {
+	join, err := logicalOperator(fs.Operator)
+	if err != nil {
+		return "", err
+	}
+
+	parts, err := compileFilterItems(fs.Items, "")
+	if err != nil {
+		return "", err
+	}
+	groups, err := compileFilterGroups(fs.Groups, "group ", 1)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append(parts, groups...), " "+join+" "), nil
+}
+
+// compileFilterGroups compiles the groups nested depth deep. Errors name a
+// group by its path, e.g. "group 0.2" for the third group of the first.
+// Groups with nothing in them are left out.
+func compileFilterGroups(groups []v1beta1.FilterGroup, path string, depth int) ([]string, error) {
+	parts := make([]string, 0, len(groups))
+	for i, g := range groups {
+		name := path + strconv.Itoa(i)
+		if depth > maxFilterGroupDepth {
+			return nil, errors.Errorf("%s: groups nest more than %d deep", name, maxFilterGroupDepth)
+		}
+		expr, err := compileFilterGroup(g, name, depth)
+		if err != nil {
+			return nil, err
+		}
+		if expr != "" {
+			parts = append(parts, expr)
+		}
+	}
+	return parts, nil
+}
+
+func compileFilterGroup(g v1beta1.FilterGroup, name string, depth int) (string, error) {
+	join, err := logicalOperator(g.Operator)
+	if err != nil {
+		return "", errors.Wrap(err, name)
+	}
+	parts, err := compileFilterItems(g.Items, name+" ")
+	if err != nil {
+		return "", err
+	}
+	groups, err := compileFilterGroups(g.Groups, name+".", depth+1)
+	if err != nil {
+		return "", err
+	}
+	parts = append(parts, groups...)
+	if len(parts) == 0 {
+		return "", nil
+	}
+	expr := "(" + strings.Join(parts, " "+join+" ") + ")"
+	if g.Not {
+		expr = "NOT " + expr
+	}
+	return expr, nil
+}
+
+func compileFilterItems(items []v1beta1.FilterItem, prefix string) ([]string, error) {
+	parts := make([]string, 0, len(items))
+	for i, item := range items {
+		expr, err := compileFilterItem(item)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%sitem %d", prefix, i)
+		}
+		parts = append(parts, expr)
+	}
+	return parts, nil
+}
+
+func logicalOperator(op string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(op)) {
+	case "", "AND":
+		return "AND", nil
+	case "OR":
+		return "OR", nil
+	default:
+		return "", errors.Errorf("unsupported logical operator %q", op)
+	}
+}
+
+// compileFilterItem compiles a single condition, e.g. "service.name IN
+// ('api', 'web')" or "duration_nano > 1000000".
+func compileFilterItem(item v1beta1.FilterItem) (string, error) {
+	key := item.Key.Key
+	if !filterKeyPattern.MatchString(key) {
+		return "", errors.Errorf("invalid key %q", key)
+	}
+	op, ok := lookupFilterOperator(item.Op)
+	if !ok {
+		return "", errors.Errorf("unsupported operator %q for key %s", item.Op, key)
+	}
+
+	switch op.arity {
+	case arityNone:
+		if (item.Value != nil && *item.Value != "") || len(item.Values) > 0 {
+			return "", errors.Errorf("operator %s takes no value", op.keyword)
+		}
+		return key + " " + op.keyword, nil
+
+	case aritySingle:
+		if item.Value == nil {
+			return "", errors.Errorf("operator %s requires a value", op.keyword)
+		}
+		if len(item.Values) > 0 {
+			return "", errors.Errorf("operator %s takes a single value, not values", op.keyword)
+		}
+		if strings.HasSuffix(op.keyword, "REGEXP") {
+			if _, err := regexp.Compile(*item.Value); err != nil {
+				return "", errors.Wrapf(err, "invalid regular expression for key %s", key)
+			}
+		}
+		v, err := filterLiteral(*item.Value, item.Key.DataType, op)
+		if err != nil {
+			return "", err
+		}
+		return key + " " + op.keyword + " " + v, nil
+
+	case arityList:
+		values := item.Values
+		if len(values) == 0 && item.Value != nil {
+			values = []string{*item.Value}
+		}
+		if len(values) == 0 {
+			return "", errors.Errorf("operator %s requires at least one value", op.keyword)
+		}
+		literals := make([]string, len(values))
+		for i, v := range values {
+			l, err := filterLiteral(v, item.Key.DataType, op)
+			if err != nil {
+				return "", err
+			}
+			literals[i] = l
+		}
+		return key + " " + op.keyword + " (" + strings.Join(literals, ", ") + ")", nil
+
+	default: // arityRange
+		if len(item.Values) != 2 {
+			return "", errors.Errorf("operator %s requires exactly two values", op.keyword)
+		}
+		lo, err := filterLiteral(item.Values[0], item.Key.DataType, op)
+		if err != nil {
+			return "", err
+		}
+		hi, err := filterLiteral(item.Values[1], item.Key.DataType, op)
+		if err != nil {
+			return "", err
+		}
+		return key + " " + op.keyword + " " + lo + " AND " + hi, nil
+	}
+}
+
+// filterLiteral writes v as a literal of the key's data type. Untyped
+// operands of ordered operators are written as numbers when they parse as
+// one, so "latency > 500" compares numerically rather than lexically.
+func filterLiteral(v, dataType string, op filterOperator) (string, error) {
+	if op.textual {
+		return quoteFilterString(v), nil
+	}
+	switch strings.ToLower(dataType) {
+	case "int64", "float64", "number":
+		if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			return "", errors.Errorf("value %q is not a number", v)
+		}
+		return strings.TrimSpace(v), nil
+	case "bool":
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return "", errors.Errorf("value %q is not a boolean", v)
+		}
+		return strconv.FormatBool(b), nil
+	case "":
+		if op.ordered {
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return strings.TrimSpace(v), nil
+			}
+		}
+	}
+	return quoteFilterString(v), nil
+}
+
+// quoteFilterString single-quotes s, backslash-escaping quotes and
+// backslashes as the v5 grammar's quoted text requires.
+func quoteFilterString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('\'')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\'' || s[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(s[i])
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// validateFilters compiles every structured filter in the condition so an
+// invalid one is reported before any payload is built or compared.
+func validateFilters(condition v1beta1.RuleCondition) error {
+	for _, b := range builderQueries(condition.CompositeQuery) {
+		if b.FilterExpression != "" || b.Filters == nil {
+			continue
+		}
+		if _, err := compileFilterSet(*b.Filters); err != nil {
+			return errors.Wrapf(err, "%s in query %s", errInvalidFilter, b.QueryName)
+		}
+	}
+	return nil
+}
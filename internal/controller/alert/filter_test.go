@@ -0,0 +1,367 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+)
+
+func filterItem(key, dataType, op string, value *string, values ...string) v1beta1.FilterItem {
+	return v1beta1.FilterItem{
+		Key:    v1beta1.KeyAttribute{Key: key, DataType: dataType},
+		Op:     op,
+		Value:  value,
+		Values: values,
+	}
+}
+
+func TestCompileFilterSet(t *testing.T) {
+	for name, tc := range map[string]struct {
+		in      v1beta1.FilterSet
+		want    string
+		wantErr string
+	}{
+		"Empty": {
+			in:   v1beta1.FilterSet{Operator: "AND"},
+			want: "",
+		},
+		"Equals": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("service.name", "string", "=", ptrTo("api"))}},
+			want: "service.name = 'api'",
+		},
+		"EscapesQuotesAndBackslashes": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("body", "", "contains", ptrTo(`it's C:\tmp`))}},
+			want: `body CONTAINS 'it\'s C:\\tmp'`,
+		},
+		"EmptyStringValue": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "!=", ptrTo(""))}},
+			want: "env != ''",
+		},
+		"TypedNumber": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("http.status_code", "int64", ">=", ptrTo(" 500 "))}},
+			want: "http.status_code >= 500",
+		},
+		"UntypedNumericComparison": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("duration_nano", "", ">", ptrTo("1e6"))}},
+			want: "duration_nano > 1e6",
+		},
+		"UntypedEqualityStaysString": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("version", "", "=", ptrTo("2"))}},
+			want: "version = '2'",
+		},
+		"TypedBool": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("has_error", "bool", "=", ptrTo("True"))}},
+			want: "has_error = true",
+		},
+		"InList": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("service.name", "", "in", nil, "api", "o'brien")}},
+			want: `service.name IN ('api', 'o\'brien')`,
+		},
+		"NotInNumbers": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("http.status_code", "int64", "nin", nil, "200", "204")}},
+			want: "http.status_code NOT IN (200, 204)",
+		},
+		"InSingleValue": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "IN", ptrTo("prod"))}},
+			want: "env IN ('prod')",
+		},
+		"Between": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("latency", "float64", "between", nil, "0.5", "2")}},
+			want: "latency BETWEEN 0.5 AND 2",
+		},
+		"Exists": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("trace_id", "", "exists", nil), filterItem("user.id", "", "nexists", ptrTo(""))}},
+			want: "trace_id EXISTS AND user.id NOT EXISTS",
+		},
+		"Regexp": {
+			in:   v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("k8s.pod.name", "", "regex", ptrTo(`^api-\d+$`))}},
+			want: `k8s.pod.name REGEXP '^api-\\d+$'`,
+		},
+		"OperatorSpellings": {
+			in: v1beta1.FilterSet{Operator: "or", Items: []v1beta1.FilterItem{
+				filterItem("a", "", "not_in", nil, "x"),
+				filterItem("b", "", "not like", ptrTo("%x%")),
+				filterItem("c", "", "<>", ptrTo("y")),
+			}},
+			want: "a NOT IN ('x') OR b NOT LIKE '%x%' OR c != 'y'",
+		},
+		"NestedGroups": {
+			in: v1beta1.FilterSet{
+				Operator: "AND",
+				Items:    []v1beta1.FilterItem{filterItem("env", "", "=", ptrTo("prod"))},
+				Groups: []v1beta1.FilterGroup{
+					{Operator: "OR", Items: []v1beta1.FilterItem{
+						filterItem("service.name", "", "=", ptrTo("api")),
+						filterItem("service.name", "", "=", ptrTo("web")),
+					}},
+					{Operator: "AND", Not: true, Items: []v1beta1.FilterItem{
+						filterItem("http.route", "", "=", ptrTo("/healthz")),
+					}},
+					{Operator: "OR"},
+				},
+			},
+			want: "env = 'prod' AND (service.name = 'api' OR service.name = 'web') AND NOT (http.route = '/healthz')",
+		},
+		"GroupsWithinGroups": {
+			in: v1beta1.FilterSet{
+				Operator: "OR",
+				Groups: []v1beta1.FilterGroup{
+					{Operator: "AND", Items: []v1beta1.FilterItem{filterItem("env", "", "=", ptrTo("prod"))}, Groups: []v1beta1.FilterGroup{
+						{Operator: "OR", Items: []v1beta1.FilterItem{
+							filterItem("service.name", "", "=", ptrTo("api")),
+							filterItem("service.name", "", "=", ptrTo("web")),
+						}},
+						{Not: true, Groups: []v1beta1.FilterGroup{
+							{Items: []v1beta1.FilterItem{filterItem("http.route", "", "=", ptrTo("/healthz"))}},
+						}},
+					}},
+					{Items: []v1beta1.FilterItem{filterItem("env", "", "=", ptrTo("staging"))}, Groups: []v1beta1.FilterGroup{{Operator: "OR"}}},
+				},
+			},
+			want: "(env = 'prod' AND (service.name = 'api' OR service.name = 'web') AND NOT ((http.route = '/healthz'))) OR (env = 'staging')",
+		},
+		"GroupsTooDeep": {
+			in: v1beta1.FilterSet{Groups: []v1beta1.FilterGroup{{Groups: []v1beta1.FilterGroup{{}, {Groups: []v1beta1.FilterGroup{{Groups: []v1beta1.FilterGroup{
+				{Groups: []v1beta1.FilterGroup{{Items: []v1beta1.FilterItem{filterItem("a", "", "=", ptrTo("x"))}}}},
+			}}}}}}}},
+			wantErr: "group 0.1.0.0.0: groups nest more than 4 deep",
+		},
+		"ErrorInNestedGroup": {
+			in: v1beta1.FilterSet{Groups: []v1beta1.FilterGroup{{Groups: []v1beta1.FilterGroup{
+				{Operator: "XOR"},
+			}}}},
+			wantErr: `group 0.0: unsupported logical operator "XOR"`,
+		},
+		"MissingValue": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "=", nil)}},
+			wantErr: "item 0: operator = requires a value",
+		},
+		"ExistsWithValue": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "exists", ptrTo("prod"))}},
+			wantErr: "takes no value",
+		},
+		"EmptyList": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "in", nil)}},
+			wantErr: "requires at least one value",
+		},
+		"BetweenOneBound": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("latency", "", "between", nil, "1")}},
+			wantErr: "requires exactly two values",
+		},
+		"NotANumber": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("code", "int64", "=", ptrTo("abc"))}},
+			wantErr: `value "abc" is not a number`,
+		},
+		"NotABool": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("ok", "bool", "=", ptrTo("maybe"))}},
+			wantErr: `value "maybe" is not a boolean`,
+		},
+		"BadRegexp": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("msg", "", "regexp", ptrTo("(unclosed"))}},
+			wantErr: "invalid regular expression",
+		},
+		"UnknownOperator": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("msg", "", "~=", ptrTo("x"))}},
+			wantErr: `unsupported operator "~="`,
+		},
+		"InvalidKey": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("a b", "", "=", ptrTo("x"))}},
+			wantErr: `invalid key "a b"`,
+		},
+		"EmptyKey": {
+			in:      v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("", "", "=", ptrTo("x"))}},
+			wantErr: `invalid key ""`,
+		},
+		"BadLogicalOperator": {
+			in:      v1beta1.FilterSet{Operator: "XOR"},
+			wantErr: `unsupported logical operator "XOR"`,
+		},
+		"ErrorInGroup": {
+			in: v1beta1.FilterSet{Groups: []v1beta1.FilterGroup{
+				{Items: []v1beta1.FilterItem{filterItem("a", "", "=", ptrTo("x")), filterItem("b", "", "in", nil)}},
+			}},
+			wantErr: "group 0 item 1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := compileFilterSet(tc.in)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("compileFilterSet() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("compileFilterSet() failed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("compileFilterSet()\ngot:  %s\nwant: %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateFilters(t *testing.T) {
+	cond := v1beta1.RuleCondition{CompositeQuery: v1beta1.CompositeQuery{Builder: &v1beta1.QueryBuilder{
+		QueryName: "B",
+		Filters:   &v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("env", "", "=", nil)}},
+	}}}
+	err := validateFilters(cond)
+	if err == nil || !strings.Contains(err.Error(), errInvalidFilter+" in query B") {
+		t.Fatalf("validateFilters() error = %v, want invalid filter in query B", err)
+	}
+
+	// A raw FilterExpression takes precedence, so the structured filters
+	// are never sent and aren't validated.
+	cond.CompositeQuery.Builder.FilterExpression = "env = 'prod'"
+	if err := validateFilters(cond); err != nil {
+		t.Errorf("validateFilters() with FilterExpression = %v, want nil", err)
+	}
+}
+
+// unquoteFilterString is the inverse of quoteFilterString, following the
+// grammar's quoted text rule: a backslash escapes the next character and an
+// unescaped quote ends the string. It reports false if s isn't exactly one
+// quoted string.
+func unquoteFilterString(s string) (string, bool) {
+	if len(s) < 2 || s[0] != '\'' {
+		return "", false
+	}
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+			if i >= len(s) {
+				return "", false
+			}
+			b.WriteByte(s[i])
+		case '\'':
+			return b.String(), i == len(s)-1
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", false
+}
+
+// FuzzCompileFilterItem checks that any string operand round-trips through
+// quoting, so a value can never terminate its literal early and inject
+// filter syntax, and that arbitrary operators never panic the compiler.
+func FuzzCompileFilterItem(f *testing.F) {
+	for _, seed := range []struct{ value, op string }{
+		{"api", "="},
+		{"it's", "contains"},
+		{`C:\path\`, "like"},
+		{`' OR 1=1 OR '`, "!="},
+		{`\'`, "in"},
+		{"", "exists"},
+		{"1", "between"},
+	} {
+		f.Add(seed.value, seed.op)
+	}
+
+	f.Fuzz(func(t *testing.T, value, op string) {
+		expr, err := compileFilterItem(filterItem("k", "string", "=", &value))
+		if err != nil {
+			t.Fatalf("compileFilterItem(%q) failed: %v", value, err)
+		}
+		literal := strings.TrimPrefix(expr, "k = ")
+		unquoted, ok := unquoteFilterString(literal)
+		if !ok || unquoted != value {
+			t.Fatalf("value %q compiled to %q, which does not round-trip (got %q)", value, literal, unquoted)
+		}
+
+		got, err := compileFilterSet(v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("k", "", op, &value)}})
+		if err == nil && !strings.HasPrefix(got, "k ") {
+			t.Fatalf("expression %q does not start with the key", got)
+		}
+	})
+}
+
+// fuzzFilterGroups builds nested filter groups from data: each byte picks a
+// group's operator, negation, number of items and number of subgroups.
+func fuzzFilterGroups(data []byte, depth int) ([]v1beta1.FilterGroup, []byte) {
+	if len(data) == 0 || depth > maxFilterGroupDepth+1 {
+		return nil, data
+	}
+	n := int(data[0] % 3)
+	data = data[1:]
+	groups := make([]v1beta1.FilterGroup, 0, n)
+	for i := 0; i < n && len(data) > 0; i++ {
+		b := data[0]
+		data = data[1:]
+		g := v1beta1.FilterGroup{Operator: "AND", Not: b&2 != 0}
+		if b&1 != 0 {
+			g.Operator = "OR"
+		}
+		for j := 0; j < int(b>>2)%3; j++ {
+			g.Items = append(g.Items, filterItem("k"+strconv.Itoa(j), "", "=", ptrTo("v")))
+		}
+		g.Groups, data = fuzzFilterGroups(data, depth+1)
+		groups = append(groups, g)
+	}
+	return groups, data
+}
+
+// filterGroupDepth returns how deeply the groups of expr are parenthesised,
+// or -1 if the parentheses don't balance.
+func filterGroupDepth(expr string) int {
+	depth, deepest := 0, 0
+	for _, r := range expr {
+		switch r {
+		case '(':
+			depth++
+			if depth > deepest {
+				deepest = depth
+			}
+		case ')':
+			depth--
+			if depth < 0 {
+				return -1
+			}
+		}
+	}
+	if depth != 0 {
+		return -1
+	}
+	return deepest
+}
+
+func FuzzCompileFilterGroups(f *testing.F) {
+	f.Add([]byte{1, 5, 2, 6, 9, 0})
+	f.Add([]byte{2, 4, 1, 4, 1, 4, 1, 4, 1, 4, 0, 3})
+	f.Add([]byte{2, 0, 0, 1, 7, 1, 2, 0})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		groups, _ := fuzzFilterGroups(data, 1)
+		expr, err := compileFilterSet(v1beta1.FilterSet{Operator: "AND", Groups: groups})
+		if err != nil {
+			if !strings.Contains(err.Error(), "groups nest more than") {
+				t.Fatalf("compileFilterSet() failed: %v", err)
+			}
+			return
+		}
+		if d := filterGroupDepth(expr); d < 0 || d > maxFilterGroupDepth {
+			t.Fatalf("expression %q is parenthesised %d deep, want 0-%d", expr, d, maxFilterGroupDepth)
+		}
+	})
+}
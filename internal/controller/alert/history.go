@@ -0,0 +1,198 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// historyLookback bounds the rule state history queried each poll
+	// interval. Transitions older than this are not considered, so an alert
+	// that has been firing continuously for longer keeps the series
+	// recorded by an earlier Observe.
+	historyLookback = 24 * time.Hour
+
+	// historyTimelineLimit caps the number of per-series transitions
+	// fetched from the timeline endpoint.
+	historyTimelineLimit = 200
+
+	// maxTopFiringSeries caps status.atProvider.topFiringSeries so a rule
+	// with high-cardinality labels can't bloat the object.
+	maxTopFiringSeries = 5
+
+	stateFiring   = "firing"
+	stateInactive = "inactive"
+)
+
+// observeRuleHistory queries the rule state history endpoints, at most once
+// per poll interval, and records the live state, last fired/resolved times
+// and firing series on the Alert's status; ruleState, the state reported on
+// the rule itself, is the fallback. History is informational: failures are
+// logged and leave the previously observed values in place rather than
+// failing Observe.
+func (c *external) observeRuleHistory(ctx context.Context, cr *v1beta1.Alert, alertID, ruleState string, now time.Time) {
+	obs := &cr.Status.AtProvider
+	if last := obs.HistoryObservedAt; last != nil && c.pollInterval > 0 && now.Before(last.Add(c.pollInterval)) {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	query := &clients.RuleStateHistoryQuery{
+		Start: now.Add(-historyLookback).UnixMilli(),
+		End:   now.UnixMilli(),
+		Limit: historyTimelineLimit,
+		Order: "desc",
+	}
+
+	periods, errPeriods := c.service.GetRuleOverallStatus(ctx, alertID, query)
+	if errPeriods != nil {
+		logger.V(1).Info("Cannot get rule overall status", "id", alertID, "error", errPeriods)
+		periods = nil
+	}
+
+	timeline, errTimeline := c.service.GetRuleStateTimeline(ctx, alertID, query)
+	if errTimeline != nil {
+		logger.V(1).Info("Cannot get rule state timeline", "id", alertID, "error", errTimeline)
+		timeline = nil
+	}
+
+	obs.State = ruleState
+	applyRuleHistory(obs, periods, timeline)
+	// A failed query is retried on the next Observe.
+	if errPeriods == nil && errTimeline == nil {
+		obs.HistoryObservedAt = &metav1.Time{Time: now}
+	}
+}
+
+// applyRuleHistory folds the overall state periods and per-series timeline
+// into obs. The current state comes from the newest overall period, then
+// the newest timeline transition; if neither is available the state copied
+// from the rule payload is kept. A nil timeline leaves the firing series
+// untouched.
+func applyRuleHistory(obs *v1beta1.AlertObservation, periods []clients.RuleStatePeriod, timeline *clients.RuleStateTimeline) {
+	var items []clients.RuleStateHistory
+	if timeline != nil {
+		items = make([]clients.RuleStateHistory, len(timeline.Items))
+		copy(items, timeline.Items)
+		sort.SliceStable(items, func(i, j int) bool { return items[i].UnixMilli > items[j].UnixMilli })
+	}
+
+	if state := currentState(periods, items); state != "" {
+		obs.State = state
+	}
+
+	var lastFired, lastResolved int64
+	for i, p := range periods {
+		if p.State != stateFiring {
+			continue
+		}
+		lastFired = max(lastFired, p.Start)
+		if i < len(periods)-1 {
+			lastResolved = max(lastResolved, p.End)
+		}
+	}
+	for _, it := range items {
+		if !it.OverallStateChanged {
+			continue
+		}
+		switch it.OverallState {
+		case stateFiring:
+			lastFired = max(lastFired, it.UnixMilli)
+		case stateInactive:
+			lastResolved = max(lastResolved, it.UnixMilli)
+		}
+	}
+	if lastFired > 0 {
+		obs.LastFiredTime = millisToTime(lastFired)
+	}
+	if lastResolved > 0 {
+		obs.LastResolvedTime = millisToTime(lastResolved)
+	}
+
+	if timeline == nil {
+		return
+	}
+	if obs.State != stateFiring {
+		obs.FiringSeries = 0
+		obs.TopFiringSeries = nil
+		return
+	}
+
+	firing := firingSeries(items)
+	if len(firing) == 0 {
+		// Still firing but no transitions in the lookback window: the
+		// series recorded by an earlier Observe are still accurate.
+		return
+	}
+	obs.FiringSeries = len(firing)
+	if len(firing) > maxTopFiringSeries {
+		firing = firing[:maxTopFiringSeries]
+	}
+	obs.TopFiringSeries = firing
+}
+
+// currentState returns the rule's overall state from the newest period, or
+// failing that the newest timeline transition. items must be sorted newest
+// first.
+func currentState(periods []clients.RuleStatePeriod, items []clients.RuleStateHistory) string {
+	var newest *clients.RuleStatePeriod
+	for i := range periods {
+		if newest == nil || periods[i].Start >= newest.Start {
+			newest = &periods[i]
+		}
+	}
+	if newest != nil && newest.State != "" {
+		return newest.State
+	}
+	if len(items) > 0 {
+		return items[0].OverallState
+	}
+	return ""
+}
+
+// firingSeries returns the series whose most recent transition is to
+// firing, most recently fired first. items must be sorted newest first.
+func firingSeries(items []clients.RuleStateHistory) []v1beta1.FiringSeries {
+	seen := make(map[uint64]bool, len(items))
+	var out []v1beta1.FiringSeries
+	for _, it := range items {
+		if seen[it.Fingerprint] {
+			continue
+		}
+		seen[it.Fingerprint] = true
+		if it.State != stateFiring {
+			continue
+		}
+		out = append(out, v1beta1.FiringSeries{
+			Labels: it.Labels,
+			Since:  millisToTime(it.UnixMilli),
+		})
+	}
+	return out
+}
+
+func millisToTime(ms int64) *metav1.Time {
+	return &metav1.Time{Time: time.UnixMilli(ms).UTC()}
+}
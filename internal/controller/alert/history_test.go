@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+)
+
+func TestApplyRuleHistory_Firing(t *testing.T) {
+	obs := &v1beta1.AlertObservation{State: "inactive"}
+
+	periods := []clients.RuleStatePeriod{
+		{State: "inactive", Start: 1000, End: 2000},
+		{State: "firing", Start: 2000, End: 3000},
+		{State: "inactive", Start: 3000, End: 4000},
+		{State: "firing", Start: 4000, End: 9000},
+	}
+	timeline := &clients.RuleStateTimeline{
+		Items: []clients.RuleStateHistory{
+			// Deliberately unsorted: applyRuleHistory must not rely on
+			// the API's ordering.
+			{Fingerprint: 1, State: "firing", OverallState: "firing", OverallStateChanged: true, UnixMilli: 4000, Labels: clients.RuleHistoryLabels{"service": "api"}},
+			{Fingerprint: 2, State: "firing", OverallState: "firing", UnixMilli: 5000, Labels: clients.RuleHistoryLabels{"service": "web"}},
+			{Fingerprint: 3, State: "inactive", OverallState: "firing", UnixMilli: 4500},
+			{Fingerprint: 3, State: "firing", OverallState: "firing", UnixMilli: 4200},
+			{Fingerprint: 1, State: "inactive", OverallState: "inactive", OverallStateChanged: true, UnixMilli: 3000},
+		},
+	}
+
+	applyRuleHistory(obs, periods, timeline)
+
+	if obs.State != "firing" {
+		t.Errorf("State = %q, want firing", obs.State)
+	}
+	if obs.LastFiredTime == nil || obs.LastFiredTime.UnixMilli() != 4000 {
+		t.Errorf("LastFiredTime = %v, want 4000ms", obs.LastFiredTime)
+	}
+	if obs.LastResolvedTime == nil || obs.LastResolvedTime.UnixMilli() != 3000 {
+		t.Errorf("LastResolvedTime = %v, want 3000ms", obs.LastResolvedTime)
+	}
+	if obs.FiringSeries != 2 {
+		t.Fatalf("FiringSeries = %d, want 2 (series 3 has since resolved)", obs.FiringSeries)
+	}
+	if got := obs.TopFiringSeries[0].Labels["service"]; got != "web" {
+		t.Errorf("TopFiringSeries[0] service = %q, want web (most recent first)", got)
+	}
+	if got := obs.TopFiringSeries[1].Labels["service"]; got != "api" {
+		t.Errorf("TopFiringSeries[1] service = %q, want api", got)
+	}
+}
+
+func TestApplyRuleHistory_InactiveClearsSeries(t *testing.T) {
+	obs := &v1beta1.AlertObservation{
+		State:           "firing",
+		FiringSeries:    3,
+		TopFiringSeries: []v1beta1.FiringSeries{{Labels: map[string]string{"a": "b"}}},
+	}
+
+	applyRuleHistory(obs, []clients.RuleStatePeriod{{State: "inactive", Start: 1, End: 2}}, &clients.RuleStateTimeline{})
+
+	if obs.State != "inactive" {
+		t.Errorf("State = %q, want inactive", obs.State)
+	}
+	if obs.FiringSeries != 0 || obs.TopFiringSeries != nil {
+		t.Errorf("expected firing series cleared, got %d / %v", obs.FiringSeries, obs.TopFiringSeries)
+	}
+}
+
+func TestApplyRuleHistory_NoHistoryKeepsRuleState(t *testing.T) {
+	obs := &v1beta1.AlertObservation{State: "nodata", FiringSeries: 1}
+
+	applyRuleHistory(obs, nil, nil)
+
+	if obs.State != "nodata" {
+		t.Errorf("State = %q, want the rule payload's state to be kept", obs.State)
+	}
+	if obs.FiringSeries != 1 {
+		t.Errorf("FiringSeries = %d, want untouched when the timeline is unavailable", obs.FiringSeries)
+	}
+}
+
+func TestApplyRuleHistory_CapsTopFiringSeries(t *testing.T) {
+	obs := &v1beta1.AlertObservation{}
+	timeline := &clients.RuleStateTimeline{}
+	for i := 0; i < maxTopFiringSeries+3; i++ {
+		timeline.Items = append(timeline.Items, clients.RuleStateHistory{
+			Fingerprint:  uint64(i),
+			State:        "firing",
+			OverallState: "firing",
+			UnixMilli:    int64(1000 + i),
+		})
+	}
+
+	applyRuleHistory(obs, nil, timeline)
+
+	if obs.FiringSeries != maxTopFiringSeries+3 {
+		t.Errorf("FiringSeries = %d, want %d", obs.FiringSeries, maxTopFiringSeries+3)
+	}
+	if len(obs.TopFiringSeries) != maxTopFiringSeries {
+		t.Errorf("len(TopFiringSeries) = %d, want %d", len(obs.TopFiringSeries), maxTopFiringSeries)
+	}
+}
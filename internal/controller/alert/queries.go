@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+)
+
+const (
+	errInvalidQueries        = "invalid composite query"
+	defaultSelectedQueryName = "A"
+)
+
+// formulaIdentifier matches the identifiers in a formula expression. Those
+// followed by "(" are function calls (abs, sqrt, ...); the rest must name a
+// builder query.
+var formulaIdentifier = regexp.MustCompile(`\b[A-Za-z_][A-Za-z0-9_]*\b`)
+
+// builderQueries returns Builder followed by Builders, with unnamed queries
+// named by position (A, B, C...).
+func builderQueries(query v1beta1.CompositeQuery) []v1beta1.QueryBuilder {
+	var out []v1beta1.QueryBuilder
+	if query.Builder != nil {
+		out = append(out, *query.Builder)
+	}
+	out = append(out, query.Builders...)
+	for i := range out {
+		if out[i].QueryName == "" {
+			out[i].QueryName = string(rune('A' + i%26))
+		}
+	}
+	return out
+}
+
+// selectedQueryName returns the query the condition is evaluated against:
+// the explicit SelectedQueryName, else the last formula, else "A".
+func selectedQueryName(condition v1beta1.RuleCondition) string {
+	if condition.SelectedQueryName != "" {
+		return condition.SelectedQueryName
+	}
+	if f := condition.CompositeQuery.Formulas; len(f) > 0 {
+		return f[len(f)-1].Name
+	}
+	return defaultSelectedQueryName
+}
+
+// convertFormula builds the v5 builder_formula envelope spec.
+func convertFormula(formula v1beta1.QueryFormula) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       formula.Name,
+		"expression": formula.Expression,
+		"legend":     formula.Legend,
+		"disabled":   formula.Disabled,
+	}
+}
+
+// validateCondition checks the parts of a condition SigNoz would only
+// reject with an opaque 400, or worse accept and never fire: query names
+// must be unique, formulas may only reference builder queries, the selected
+// query must exist and structured filters must compile.
+func validateCondition(condition v1beta1.RuleCondition) error {
+	if err := validateQueries(condition); err != nil {
+		return errors.Wrap(err, errInvalidQueries)
+	}
+	return validateFilters(condition)
+}
+
+func validateQueries(condition v1beta1.RuleCondition) error {
+	query := condition.CompositeQuery
+	names := map[string]bool{}
+	add := func(name string) error {
+		if names[name] {
+			return errors.Errorf("duplicate query name %s", name)
+		}
+		names[name] = true
+		return nil
+	}
+
+	builders := map[string]bool{}
+	for _, b := range builderQueries(query) {
+		if err := add(b.QueryName); err != nil {
+			return err
+		}
+		builders[b.QueryName] = true
+	}
+	for i, pq := range query.PromQL {
+		if err := add(promQueryName(pq.Name, i)); err != nil {
+			return err
+		}
+	}
+	for i, chq := range query.ClickHouse {
+		if err := add(promQueryName(chq.Name, i)); err != nil {
+			return err
+		}
+	}
+	for _, f := range query.Formulas {
+		if err := add(f.Name); err != nil {
+			return err
+		}
+		if err := validateFormula(f, builders); err != nil {
+			return err
+		}
+	}
+
+	// Only an explicit name is checked: the "A" default predates
+	// Formulas and is kept for rules whose only query is named otherwise.
+	if name := condition.SelectedQueryName; name != "" && !names[name] {
+		return errors.Errorf("selected query %s is not defined", name)
+	}
+	return nil
+}
+
+func validateFormula(f v1beta1.QueryFormula, builders map[string]bool) error {
+	refs := 0
+	for _, loc := range formulaIdentifier.FindAllStringIndex(f.Expression, -1) {
+		if strings.HasPrefix(strings.TrimLeft(f.Expression[loc[1]:], " "), "(") {
+			continue
+		}
+		ref := f.Expression[loc[0]:loc[1]]
+		if !builders[ref] {
+			return errors.Errorf("formula %s references unknown builder query %s", f.Name, ref)
+		}
+		refs++
+	}
+	if refs == 0 {
+		return errors.Errorf("formula %s references no builder queries", f.Name)
+	}
+	return nil
+}
+
+// promQueryName returns the name a PromQL or ClickHouse query is sent
+// under.
+func promQueryName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("A%d", index)
+	}
+	return name
+}
+
+// QueryEnvelopes returns the v5 query envelopes of a composite query, in the
+// form both the rules API and the query_range API accept.
+func QueryEnvelopes(query v1beta1.CompositeQuery) []interface{} {
+	queries, _ := convertCompositeQuery(query)["queries"].([]interface{})
+	return queries
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+)
+
+// errorRatioCondition is the "errors / requests > 5%" ratio alert that
+// motivated multiple builder queries.
+func errorRatioCondition() v1beta1.RuleCondition {
+	return v1beta1.RuleCondition{
+		CompositeQuery: v1beta1.CompositeQuery{
+			Builder: &v1beta1.QueryBuilder{
+				DataSource:         "metrics",
+				AggregateAttribute: &v1beta1.KeyAttribute{Key: "http_requests_errors_total"},
+				TimeAggregation:    "rate",
+				SpaceAggregation:   "sum",
+			},
+			Builders: []v1beta1.QueryBuilder{{
+				DataSource:         "metrics",
+				AggregateAttribute: &v1beta1.KeyAttribute{Key: "http_requests_total"},
+				TimeAggregation:    "rate",
+				SpaceAggregation:   "sum",
+			}},
+			Formulas: []v1beta1.QueryFormula{{Name: "F1", Expression: "A/B*100"}},
+		},
+		CompareOp: ">",
+		Target:    float64Ptr(5),
+	}
+}
+
+// roundTrip simulates the rule as SigNoz returns it: the desired payload
+// encoded to JSON and decoded back into generic maps.
+func roundTrip(t *testing.T, v map[string]interface{}) map[string]interface{} {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("cannot marshal condition: %v", err)
+	}
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("cannot unmarshal condition: %v", err)
+	}
+	return out
+}
+
+func queryEnvelopes(t *testing.T, cond map[string]interface{}) []interface{} {
+	t.Helper()
+	queries, ok := cond["compositeQuery"].(map[string]interface{})["queries"].([]interface{})
+	if !ok {
+		t.Fatalf("expected compositeQuery.queries array, got %v", cond["compositeQuery"])
+	}
+	return queries
+}
+
+func TestConvertCondition_BuilderQueriesAndFormulas(t *testing.T) {
+	got := convertCondition(errorRatioCondition())
+
+	if got["selectedQueryName"] != "F1" {
+		t.Errorf("selectedQueryName = %v, want F1 (the last formula)", got["selectedQueryName"])
+	}
+	if qt := got["compositeQuery"].(map[string]interface{})["queryType"]; qt != "builder" {
+		t.Errorf("queryType = %v, want builder", qt)
+	}
+
+	queries := queryEnvelopes(t, got)
+	want := []struct{ typ, name string }{
+		{"builder_query", "A"},
+		{"builder_query", "B"},
+		{"builder_formula", "F1"},
+	}
+	if len(queries) != len(want) {
+		t.Fatalf("expected %d envelopes, got %d", len(want), len(queries))
+	}
+	for i, w := range want {
+		env := queries[i].(map[string]interface{})
+		spec := env["spec"].(map[string]interface{})
+		if env["type"] != w.typ || spec["name"] != w.name {
+			t.Errorf("envelope %d = %v/%v, want %s/%s", i, env["type"], spec["name"], w.typ, w.name)
+		}
+	}
+	formula := queries[2].(map[string]interface{})["spec"].(map[string]interface{})
+	if formula["expression"] != "A/B*100" {
+		t.Errorf("formula expression = %v, want A/B*100", formula["expression"])
+	}
+}
+
+func TestSelectedQueryName(t *testing.T) {
+	cond := errorRatioCondition()
+	cond.SelectedQueryName = "B"
+	if got := selectedQueryName(cond); got != "B" {
+		t.Errorf("selectedQueryName() = %q, want the explicit B", got)
+	}
+
+	cond = v1beta1.RuleCondition{CompositeQuery: v1beta1.CompositeQuery{Builder: &v1beta1.QueryBuilder{DataSource: "metrics"}}}
+	if got := selectedQueryName(cond); got != "A" {
+		t.Errorf("selectedQueryName() = %q, want A without formulas", got)
+	}
+}
+
+func TestConditionEqual_MultipleEnvelopes(t *testing.T) {
+	desired := convertCondition(errorRatioCondition())
+
+	if !conditionEqual(desired, roundTrip(t, desired)) {
+		t.Fatal("expected a multi-query condition to equal its own round trip")
+	}
+
+	// Envelopes are compared positionally, so a reordered rule drifts.
+	reordered := roundTrip(t, desired)
+	queries := queryEnvelopes(t, reordered)
+	queries[0], queries[1] = queries[1], queries[0]
+	if conditionEqual(desired, reordered) {
+		t.Error("expected reordered builder queries to be detected as drift")
+	}
+
+	changed := roundTrip(t, desired)
+	queries = queryEnvelopes(t, changed)
+	queries[2].(map[string]interface{})["spec"].(map[string]interface{})["expression"] = "A/B"
+	if conditionEqual(desired, changed) {
+		t.Error("expected a changed formula expression to be detected as drift")
+	}
+	var d clients.Diff
+	diffCondition(&d, "condition", desired, changed)
+	if want := "condition.compositeQuery.queries[2].spec.expression"; len(d) != 1 || d[0].Path != want {
+		t.Errorf("diffCondition() = %s, want a single difference at %s", d, want)
+	}
+
+	missing := roundTrip(t, desired)
+	missing["compositeQuery"].(map[string]interface{})["queries"] = queryEnvelopes(t, missing)[:2]
+	if conditionEqual(desired, missing) {
+		t.Error("expected a missing formula envelope to be detected as drift")
+	}
+}
+
+func TestValidateCondition(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutate  func(*v1beta1.RuleCondition)
+		wantErr string
+	}{
+		"Valid": {
+			mutate: func(*v1beta1.RuleCondition) {},
+		},
+		"FunctionCall": {
+			mutate: func(c *v1beta1.RuleCondition) {
+				c.CompositeQuery.Formulas[0].Expression = "abs (A - B) / B"
+			},
+		},
+		"ExplicitSelectedQuery": {
+			mutate: func(c *v1beta1.RuleCondition) { c.SelectedQueryName = "A" },
+		},
+		"UnknownSelectedQuery": {
+			mutate:  func(c *v1beta1.RuleCondition) { c.SelectedQueryName = "F2" },
+			wantErr: "selected query F2 is not defined",
+		},
+		"UnknownFormulaReference": {
+			mutate:  func(c *v1beta1.RuleCondition) { c.CompositeQuery.Formulas[0].Expression = "A/C" },
+			wantErr: "formula F1 references unknown builder query C",
+		},
+		"FormulaWithoutReferences": {
+			mutate:  func(c *v1beta1.RuleCondition) { c.CompositeQuery.Formulas[0].Expression = "1e6 * 2" },
+			wantErr: "formula F1 references no builder queries",
+		},
+		"DuplicateName": {
+			mutate:  func(c *v1beta1.RuleCondition) { c.CompositeQuery.Builders[0].QueryName = "A" },
+			wantErr: "duplicate query name A",
+		},
+		"FormulaShadowsQuery": {
+			mutate:  func(c *v1beta1.RuleCondition) { c.CompositeQuery.Formulas[0].Name = "B" },
+			wantErr: "duplicate query name B",
+		},
+		"InvalidFilterInSecondQuery": {
+			mutate: func(c *v1beta1.RuleCondition) {
+				c.CompositeQuery.Builders[0].Filters = &v1beta1.FilterSet{Items: []v1beta1.FilterItem{filterItem("code", "int64", "=", ptrTo("x"))}}
+			},
+			wantErr: errInvalidFilter + " in query B",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cond := errorRatioCondition()
+			tc.mutate(&cond)
+			err := validateCondition(cond)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("validateCondition() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("validateCondition() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	channelv1beta1 "github.com/rossigee/provider-signoz/apis/channel/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TypeReferencesResolved is set on an Alert to report whether every
+// NotificationChannel it references has been created in SigNoz. While it is
+// False the controller neither creates nor updates the rule, since SigNoz
+// rejects rules naming channels it doesn't know about.
+const TypeReferencesResolved xpv1.ConditionType = "ReferencesResolved"
+
+const (
+	ReasonChannelsPending    = "ChannelsPending"
+	ReasonChannelsResolved   = "ChannelsResolved"
+	ReasonNoChannelsSelected = "NoChannelsSelected"
+)
+
+const (
+	errReferencesPending    = "referenced notification channels are not ready"
+	errEmptyChannelSelector = "channel selector must set matchLabels, matchExpressions or matchControllerRef"
+	errInvalidChannelSel    = "invalid channel selector"
+	errNoChannelsSelected   = "channel selector matched no notification channels"
+)
+
+// errNoChannelsMatched is returned when a channel selector matches no
+// NotificationChannel. Observe reports it on the ReferencesResolved
+// condition rather than failing, so an Alert whose channels were deleted
+// first can still be deleted; Create and Update fail with it.
+var errNoChannelsMatched = errors.New(errNoChannelsSelected)
+
+// channelReady reports whether a NotificationChannel exists in SigNoz: it
+// must be Ready and carry the external name of the upstream channel.
+func channelReady(channel *channelv1beta1.NotificationChannel) bool {
+	return channel.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue &&
+		meta.GetExternalName(channel) != ""
+}
+
+// setReferencesCondition records which referenced channels, if any, are
+// still pending on the Alert's ReferencesResolved condition.
+func setReferencesCondition(cr *v1beta1.Alert, pending []string) {
+	if len(pending) == 0 {
+		cr.Status.SetConditions(xpv1.Condition{
+			Type:   TypeReferencesResolved,
+			Status: corev1.ConditionTrue,
+			Reason: ReasonChannelsResolved,
+		})
+		return
+	}
+	cr.Status.SetConditions(xpv1.Condition{
+		Type:    TypeReferencesResolved,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReasonChannelsPending,
+		Message: fmt.Sprintf("Waiting for NotificationChannels to become ready: %s", strings.Join(pending, ", ")),
+	})
+}
+
+// setNoChannelsCondition records on the Alert's ReferencesResolved
+// condition that a channel selector matched no NotificationChannel.
+func setNoChannelsCondition(cr *v1beta1.Alert, err error) {
+	cr.Status.SetConditions(xpv1.Condition{
+		Type:    TypeReferencesResolved,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReasonNoChannelsSelected,
+		Message: err.Error(),
+	})
+}
+
+// alertReferencesChannel reports whether the Alert names the channel in any
+// of its alert-level or per-threshold refs, or selects it by label.
+func alertReferencesChannel(cr *v1beta1.Alert, channel *channelv1beta1.NotificationChannel) bool {
+	if cr.GetNamespace() != channel.GetNamespace() {
+		return false
+	}
+	p := cr.Spec.ForProvider
+	if refsName(p.ChannelIDsRef, channel.GetName()) || selects(cr, p.ChannelIDsSelector, channel) {
+		return true
+	}
+	for _, t := range p.Condition.Thresholds {
+		if refsName(t.ChannelRefs, channel.GetName()) || selects(cr, t.ChannelSelector, channel) {
+			return true
+		}
+	}
+	return false
+}
+
+func refsName(refs []xpv1.Reference, name string) bool {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selects reports whether the Alert's selector matches the channel. An
+// invalid or empty selector matches nothing.
+func selects(cr metav1.Object, selector *v1beta1.ChannelSelector, channel *channelv1beta1.NotificationChannel) bool {
+	if selector == nil {
+		return false
+	}
+	s, err := labelSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(channel.GetLabels())) && controllerMatches(cr, selector, channel)
+}
+
+// labelSelector converts the label criteria of a ChannelSelector into a
+// labels.Selector. A selector with no criteria at all is an error, since
+// it would otherwise route the alert to every channel in the namespace.
+func labelSelector(selector *v1beta1.ChannelSelector) (labels.Selector, error) {
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 && !controllersMustMatch(selector) {
+		return nil, errors.New(errEmptyChannelSelector)
+	}
+	s, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      selector.MatchLabels,
+		MatchExpressions: selector.MatchExpressions,
+	})
+	return s, errors.Wrap(err, errInvalidChannelSel)
+}
+
+func controllersMustMatch(selector *v1beta1.ChannelSelector) bool {
+	return selector.MatchControllerRef != nil && *selector.MatchControllerRef
+}
+
+// controllerMatches applies MatchControllerRef: when set, the channel must
+// be controlled by the same object as the Alert.
+func controllerMatches(cr metav1.Object, selector *v1beta1.ChannelSelector, channel metav1.Object) bool {
+	return !controllersMustMatch(selector) || meta.HaveSameController(cr, channel)
+}
+
+// alertsForChannel maps a NotificationChannel event to reconcile requests
+// for every Alert in its namespace that references it, so an Alert
+// waiting on a channel is retried as soon as that channel changes.
+func alertsForChannel(kube client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		channel, ok := obj.(*channelv1beta1.NotificationChannel)
+		if !ok {
+			return nil
+		}
+		alerts := &v1beta1.AlertList{}
+		if err := kube.List(ctx, alerts, client.InNamespace(channel.GetNamespace())); err != nil {
+			log.FromContext(ctx).V(1).Info("Cannot list alerts for notification channel", "channel", channel.GetName(), "error", err)
+			return nil
+		}
+		var requests []reconcile.Request
+		for i := range alerts.Items {
+			if alertReferencesChannel(&alerts.Items[i], channel) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: alerts.Items[i].GetNamespace(),
+					Name:      alerts.Items[i].GetName(),
+				}})
+			}
+		}
+		return requests
+	}
+}
+
+// channelReferenceChanged passes NotificationChannel updates that can change
+// how Alerts resolve it: readiness, external name, SigNoz channel name or
+// labels. Creates and deletes always pass.
+func channelReferenceChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCh, ok := e.ObjectOld.(*channelv1beta1.NotificationChannel)
+			if !ok {
+				return false
+			}
+			newCh, ok := e.ObjectNew.(*channelv1beta1.NotificationChannel)
+			if !ok {
+				return false
+			}
+			return channelReady(oldCh) != channelReady(newCh) ||
+				meta.GetExternalName(oldCh) != meta.GetExternalName(newCh) ||
+				oldCh.Spec.ForProvider.Name != newCh.Spec.ForProvider.Name ||
+				!labels.Equals(oldCh.GetLabels(), newCh.GetLabels())
+		},
+	}
+}
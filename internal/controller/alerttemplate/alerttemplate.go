@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alerttemplate renders Alerts from AlertTemplates.
+package alerttemplate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/controller/children"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const controllerName = "alerttemplate.alert.signoz.crossplane.io"
+
+const (
+	errGetTemplate     = "cannot get AlertTemplate"
+	errInvalidSelector = "invalid instance selector"
+	errListConfigMaps  = "cannot list instance ConfigMaps"
+	errUpdateStatus    = "cannot update AlertTemplate status"
+	errInstancesFailed = "some instances could not be rendered"
+
+	errInstancesNotWritten = "cannot write the Alerts of some instances"
+)
+
+// Setup adds a controller that reconciles AlertTemplates.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	r := &reconciler{kube: mgr.GetClient()}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.AlertTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Child Alerts re-queue their template as they become ready, so
+		// the aggregated status follows them.
+		Owns(&v1beta1.Alert{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(templatesForConfigMap(mgr.GetClient()))).
+		Complete(r)
+}
+
+type reconciler struct {
+	kube client.Client
+}
+
+// An instance is a named set of parameter values, either listed in the
+// template or read from a selected ConfigMap.
+type instance struct {
+	v1beta1.AlertTemplateInstance
+	source string
+}
+
+// Reconcile renders an Alert for each of the template's instances, creates
+// or updates it, and deletes the Alerts of instances that no longer exist.
+// Rendered Alerts are controlled by the template, so they are garbage
+// collected with it.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	t := &v1beta1.AlertTemplate{}
+	if err := r.kube.Get(ctx, req.NamespacedName, t); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetTemplate)
+	}
+	if t.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	instances, err := r.instances(ctx, t)
+	if err != nil {
+		t.Status.SetConditions(xpv1.ReconcileError(err))
+		return reconcile.Result{}, r.updateStatus(ctx, t, err)
+	}
+
+	keep := map[string]bool{}
+	seen := map[string]string{}
+	alerts := make([]v1beta1.TemplatedAlert, 0, len(instances))
+	var failed, retry []string
+	for _, inst := range instances {
+		status := v1beta1.TemplatedAlert{Instance: inst.Name}
+		if name, err := alertName(t, inst.Name); err == nil {
+			// Keep the Alert of an instance that fails to render, rather
+			// than deleting it because of a typo.
+			keep[name] = true
+			status.Name = name
+		}
+
+		var err error
+		if src, ok := seen[inst.Name]; ok {
+			err = errors.Errorf("%s: defined by %s and %s", errDuplicateInstance, src, inst.source)
+		} else if desired, rerr := renderAlert(t, inst.AlertTemplateInstance); rerr != nil {
+			err = rerr
+		} else if err = r.apply(ctx, t, desired, &status); err != nil && !errors.Is(err, children.ErrNotControlled) {
+			// Unlike a render error or an Alert the template doesn't
+			// control, a failed write may succeed on retry, and no child
+			// Alert exists yet to requeue the template.
+			retry = append(retry, inst.Name)
+		}
+		seen[inst.Name] = inst.source
+		if err != nil {
+			status.Message = err.Error()
+			failed = append(failed, inst.Name)
+		}
+		alerts = append(alerts, status)
+	}
+
+	if err := r.prune(ctx, t, keep); err != nil {
+		t.Status.SetConditions(xpv1.ReconcileError(err))
+		return reconcile.Result{}, r.updateStatus(ctx, t, err)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Instance < alerts[j].Instance })
+	t.Status.Alerts = alerts
+	t.Status.Instances = len(alerts)
+	t.Status.ReadyAlerts = 0
+	var pending []string
+	for _, a := range alerts {
+		if a.Ready {
+			t.Status.ReadyAlerts++
+			continue
+		}
+		pending = append(pending, a.Instance)
+	}
+
+	if len(failed) > 0 {
+		t.Status.SetConditions(xpv1.ReconcileError(errors.Errorf("%s: %s", errInstancesFailed, strings.Join(failed, ", "))))
+	} else {
+		t.Status.SetConditions(xpv1.ReconcileSuccess())
+	}
+	if len(pending) > 0 {
+		t.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("Alerts not ready for instances: %s", strings.Join(pending, ", "))))
+	} else {
+		t.Status.SetConditions(xpv1.Available())
+	}
+	// Returning an error requeues the template with backoff.
+	if len(retry) > 0 {
+		return reconcile.Result{}, r.updateStatus(ctx, t, errors.Errorf("%s: %s", errInstancesNotWritten, strings.Join(retry, ", ")))
+	}
+	return reconcile.Result{}, r.updateStatus(ctx, t, nil)
+}
+
+// instances returns the template's listed instances followed by those
+// defined by ConfigMaps its instance selector matches.
+func (r *reconciler) instances(ctx context.Context, t *v1beta1.AlertTemplate) ([]instance, error) {
+	out := make([]instance, 0, len(t.Spec.Instances))
+	for _, inst := range t.Spec.Instances {
+		out = append(out, instance{AlertTemplateInstance: inst, source: "spec.instances"})
+	}
+	if t.Spec.InstanceSelector == nil {
+		return out, nil
+	}
+
+	sel, err := metav1.LabelSelectorAsSelector(t.Spec.InstanceSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidSelector)
+	}
+	cms := &corev1.ConfigMapList{}
+	if err := r.kube.List(ctx, cms, client.InNamespace(t.GetNamespace()), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		return nil, errors.Wrap(err, errListConfigMaps)
+	}
+	sort.Slice(cms.Items, func(i, j int) bool { return cms.Items[i].GetName() < cms.Items[j].GetName() })
+	for _, cm := range cms.Items {
+		out = append(out, instance{
+			AlertTemplateInstance: v1beta1.AlertTemplateInstance{Name: cm.GetName(), Parameters: cm.Data},
+			source:                "ConfigMap " + cm.GetName(),
+		})
+	}
+	return out, nil
+}
+
+// apply creates or updates an instance's rendered Alert, recording its
+// readiness in status. It returns an error if the Alert could not be
+// written, or children.ErrNotControlled if an Alert of the same name exists
+// that the template doesn't control.
+func (r *reconciler) apply(ctx context.Context, t *v1beta1.AlertTemplate, desired *v1beta1.Alert, status *v1beta1.TemplatedAlert) error {
+	desired.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(t, v1beta1.AlertTemplate_GroupVersionKind)})
+	existing := &v1beta1.Alert{}
+	created, err := children.Apply(ctx, r.kube, t, AnnotationTemplateHash, desired, existing, func() {
+		existing.Spec = desired.Spec
+	})
+	if err != nil {
+		return err
+	}
+	if created {
+		status.Message = "Alert created"
+		return nil
+	}
+
+	ready := existing.GetCondition(xpv1.TypeReady)
+	status.Ready = ready.Status == corev1.ConditionTrue
+	if !status.Ready {
+		status.Message = ready.Message
+		if status.Message == "" {
+			status.Message = "Alert is not ready"
+		}
+	}
+	return nil
+}
+
+// prune deletes Alerts controlled by the template that aren't kept.
+func (r *reconciler) prune(ctx context.Context, t *v1beta1.AlertTemplate, keep map[string]bool) error {
+	return children.Prune(ctx, r.kube, t, &v1beta1.AlertList{}, keep, client.InNamespace(t.GetNamespace()))
+}
+
+// updateStatus writes the template's status, returning err if it was
+// written successfully.
+func (r *reconciler) updateStatus(ctx context.Context, t *v1beta1.AlertTemplate, err error) error {
+	if uerr := r.kube.Status().Update(ctx, t); uerr != nil {
+		return errors.Wrap(uerr, errUpdateStatus)
+	}
+	return err
+}
+
+// templatesForConfigMap maps a ConfigMap event to reconcile requests for
+// every AlertTemplate in its namespace whose instance selector matches it.
+// Label changes are seen from both sides, so a template also hears about a
+// ConfigMap that stopped matching.
+func templatesForConfigMap(kube client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		templates := &v1beta1.AlertTemplateList{}
+		if err := kube.List(ctx, templates, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.FromContext(ctx).V(1).Info("Cannot list alert templates for ConfigMap", "configmap", obj.GetName(), "error", err)
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, t := range templates.Items {
+			if t.Spec.InstanceSelector == nil {
+				continue
+			}
+			sel, err := metav1.LabelSelectorAsSelector(t.Spec.InstanceSelector)
+			if err != nil || !sel.Matches(labels.Set(obj.GetLabels())) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: t.GetNamespace(),
+				Name:      t.GetName(),
+			}})
+		}
+		return requests
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerttemplate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/controller/children"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newFakeKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add core types to scheme: %v", err)
+	}
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add alert types to scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithStatusSubresource(&v1beta1.AlertTemplate{}).Build()
+}
+
+func reconcileTemplate(t *testing.T, kube client.Client) *v1beta1.AlertTemplate {
+	t.Helper()
+	r := &reconciler{kube: kube}
+	key := types.NamespacedName{Namespace: "monitoring", Name: "errors"}
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &v1beta1.AlertTemplate{}
+	if err := kube.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("cannot get template: %v", err)
+	}
+	return got
+}
+
+func getAlert(t *testing.T, kube client.Client, name string) (*v1beta1.Alert, error) {
+	t.Helper()
+	a := &v1beta1.Alert{}
+	err := kube.Get(context.Background(), types.NamespacedName{Namespace: "monitoring", Name: name}, a)
+	return a, err
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	tmpl := alertTemplate(
+		v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}},
+		v1beta1.AlertTemplateInstance{Name: "search", Parameters: map[string]string{"service": "search"}},
+	)
+	tmpl.Spec.InstanceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"alerts": "errors"}}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "monitoring", Labels: map[string]string{"alerts": "errors"}},
+		Data:       map[string]string{"service": "payments", "threshold": "1"},
+	}
+	kube := newFakeKube(t, tmpl, cm)
+
+	// Every instance gets an Alert controlled by the template.
+	got := reconcileTemplate(t, kube)
+	for _, name := range []string{"errors-checkout", "errors-search", "errors-payments"} {
+		a, err := getAlert(t, kube, name)
+		if err != nil {
+			t.Fatalf("expected Alert %s: %v", name, err)
+		}
+		if !metav1.IsControlledBy(a, got) {
+			t.Errorf("Alert %s is not controlled by the template", name)
+		}
+	}
+	if got.Status.Instances != 3 || got.Status.ReadyAlerts != 0 {
+		t.Errorf("status = %d instances, %d ready, want 3, 0", got.Status.Instances, got.Status.ReadyAlerts)
+	}
+	if c := got.Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionFalse {
+		t.Errorf("Ready = %s, want False while Alerts are not ready", c.Status)
+	}
+
+	// Readiness is aggregated from the Alerts.
+	for _, name := range []string{"errors-checkout", "errors-search", "errors-payments"} {
+		a, _ := getAlert(t, kube, name)
+		a.Status.SetConditions(xpv1.Available())
+		if err := kube.Update(ctx, a); err != nil {
+			t.Fatalf("cannot mark Alert ready: %v", err)
+		}
+	}
+	got = reconcileTemplate(t, kube)
+	if got.Status.ReadyAlerts != 3 {
+		t.Errorf("readyAlerts = %d, want 3", got.Status.ReadyAlerts)
+	}
+	if c := got.Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionTrue {
+		t.Errorf("Ready = %s, want True once every Alert is ready", c.Status)
+	}
+
+	// Changing a parameter updates the Alert but keeps its external name.
+	a, _ := getAlert(t, kube, "errors-checkout")
+	meta.SetExternalName(a, "42")
+	if err := kube.Update(ctx, a); err != nil {
+		t.Fatalf("cannot set external name: %v", err)
+	}
+	got.Spec.Instances[0].Parameters["threshold"] = "10"
+	if err := kube.Update(ctx, got); err != nil {
+		t.Fatalf("cannot update template: %v", err)
+	}
+	got = reconcileTemplate(t, kube)
+	a, _ = getAlert(t, kube, "errors-checkout")
+	if q := a.Spec.ForProvider.Condition.CompositeQuery.PromQL[0].Query; q != `sum(rate(errors_total{service="checkout"}[5m])) > 10` {
+		t.Errorf("query = %q, want the new threshold", q)
+	}
+	if a.GetAnnotations()["crossplane.io/external-name"] != "42" {
+		t.Error("expected the external name to survive the update")
+	}
+
+	// Removing instances prunes their Alerts.
+	got.Spec.Instances = got.Spec.Instances[:1]
+	if err := kube.Update(ctx, got); err != nil {
+		t.Fatalf("cannot update template: %v", err)
+	}
+	if err := kube.Delete(ctx, cm); err != nil {
+		t.Fatalf("cannot delete ConfigMap: %v", err)
+	}
+	got = reconcileTemplate(t, kube)
+	for _, name := range []string{"errors-search", "errors-payments"} {
+		if _, err := getAlert(t, kube, name); !kerrors.IsNotFound(err) {
+			t.Errorf("expected Alert %s to be pruned, got %v", name, err)
+		}
+	}
+	if got.Status.Instances != 1 {
+		t.Errorf("instances = %d, want 1", got.Status.Instances)
+	}
+}
+
+func TestReconcileInstanceErrors(t *testing.T) {
+	tmpl := alertTemplate(
+		v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}},
+		v1beta1.AlertTemplateInstance{Name: "search"},
+	)
+	unowned := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "errors-checkout", Namespace: "monitoring"}}
+	kube := newFakeKube(t, tmpl, unowned)
+
+	got := reconcileTemplate(t, kube)
+	if c := got.Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionFalse {
+		t.Errorf("Synced = %s, want False", c.Status)
+	}
+	want := map[string]string{
+		"checkout": "errors-checkout: " + children.ErrNotControlled.Error(),
+		"search":   errMissingParameter + " service",
+	}
+	for _, a := range got.Status.Alerts {
+		if a.Message != want[a.Instance] {
+			t.Errorf("instance %s message = %q, want %q", a.Instance, a.Message, want[a.Instance])
+		}
+	}
+	if a, _ := getAlert(t, kube, "errors-checkout"); len(a.GetOwnerReferences()) != 0 {
+		t.Error("expected an Alert the template doesn't control to be left alone")
+	}
+}
+
+func TestReconcileRetriesFailedWrites(t *testing.T) {
+	tmpl := alertTemplate(v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}})
+	s := newFakeKube(t).Scheme()
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(tmpl).WithStatusSubresource(&v1beta1.AlertTemplate{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				return kerrors.NewServiceUnavailable("etcd is down")
+			},
+		}).Build()
+
+	r := &reconciler{kube: kube}
+	key := types.NamespacedName{Namespace: "monitoring", Name: "errors"}
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err == nil || !strings.Contains(err.Error(), errInstancesNotWritten) {
+		t.Errorf("Reconcile() error = %v, want %q so the write is retried", err, errInstancesNotWritten)
+	}
+	got := &v1beta1.AlertTemplate{}
+	if err := kube.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("cannot get template: %v", err)
+	}
+	if len(got.Status.Alerts) != 1 || !strings.Contains(got.Status.Alerts[0].Message, "cannot create") {
+		t.Errorf("status.alerts = %+v, want the create error recorded", got.Status.Alerts)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerttemplate
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// AnnotationTemplateHash holds the hash of the rendered spec and
+	// metadata a templated Alert was last written from, so it is only
+	// updated when the rendering changes.
+	AnnotationTemplateHash = "signoz.m.crossplane.io/template-hash"
+
+	// instanceParameter is the built-in parameter holding the instance name.
+	instanceParameter = "instance"
+)
+
+const (
+	errRenderSpec        = "cannot render template spec"
+	errUnknownParameter  = "instance sets undeclared parameter"
+	errMissingParameter  = "instance does not set required parameter"
+	errUndeclaredPlace   = "template references undeclared parameter"
+	errDuplicateInstance = "duplicate instance"
+)
+
+// placeholder matches ${name} parameter references and the $${ escape.
+var placeholder = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// parameterValues merges an instance's parameter values over the template's
+// defaults, and checks every parameter is set and declared.
+func parameterValues(declared []v1beta1.TemplateParameter, inst v1beta1.AlertTemplateInstance) (map[string]string, error) {
+	values := map[string]string{instanceParameter: inst.Name}
+	known := map[string]bool{instanceParameter: true}
+	for _, p := range declared {
+		known[p.Name] = true
+		if p.Default != nil {
+			values[p.Name] = *p.Default
+		}
+	}
+
+	names := make([]string, 0, len(inst.Parameters))
+	for name := range inst.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] || name == instanceParameter {
+			return nil, errors.Errorf("%s %s", errUnknownParameter, name)
+		}
+		values[name] = inst.Parameters[name]
+	}
+
+	for _, p := range declared {
+		if _, ok := values[p.Name]; !ok {
+			return nil, errors.Errorf("%s %s", errMissingParameter, p.Name)
+		}
+	}
+	return values, nil
+}
+
+// substitute replaces the ${name} references in s.
+func substitute(s string, values map[string]string) (string, error) {
+	var err error
+	out := placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		if m == "$${" {
+			return "${"
+		}
+		name := m[2 : len(m)-1]
+		v, ok := values[name]
+		if !ok && err == nil {
+			err = errors.Errorf("%s %s", errUndeclaredPlace, name)
+		}
+		return v
+	})
+	return out, err
+}
+
+// substituteAll replaces parameter references in every string of a decoded
+// JSON document. Map keys are left alone.
+func substituteAll(v interface{}, values map[string]string) (interface{}, error) {
+	switch x := v.(type) {
+	case string:
+		return substitute(x, values)
+	case map[string]interface{}:
+		for k, e := range x {
+			r, err := substituteAll(e, values)
+			if err != nil {
+				return nil, err
+			}
+			x[k] = r
+		}
+	case []interface{}:
+		for i, e := range x {
+			r, err := substituteAll(e, values)
+			if err != nil {
+				return nil, err
+			}
+			x[i] = r
+		}
+	}
+	return v, nil
+}
+
+func substituteMap(m map[string]string, values map[string]string) (map[string]string, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		r, err := substitute(v, values)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = r
+	}
+	return out, nil
+}
+
+// alertName returns the name of an instance's Alert.
+func alertName(t *v1beta1.AlertTemplate, instance string) (string, error) {
+	name := t.GetName() + "-" + instance
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", errors.Errorf("invalid Alert name %q: %s", name, strings.Join(errs, ", "))
+	}
+	return name, nil
+}
+
+// renderAlert renders the Alert for one instance of the template. The
+// returned Alert carries the AnnotationTemplateHash of its rendering, but
+// no owner reference.
+func renderAlert(t *v1beta1.AlertTemplate, inst v1beta1.AlertTemplateInstance) (*v1beta1.Alert, error) {
+	name, err := alertName(t, inst.Name)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parameterValues(t.Spec.Parameters, inst)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(t.Spec.Template.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, errRenderSpec)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, errors.Wrap(err, errRenderSpec)
+	}
+	if doc, err = substituteAll(doc, values); err != nil {
+		return nil, err
+	}
+	if raw, err = json.Marshal(doc); err != nil {
+		return nil, errors.Wrap(err, errRenderSpec)
+	}
+	alert := &v1beta1.Alert{}
+	if err := json.Unmarshal(raw, &alert.Spec); err != nil {
+		return nil, errors.Wrap(err, errRenderSpec)
+	}
+
+	labels, err := substituteMap(t.Spec.Template.Metadata.Labels, values)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := substituteMap(t.Spec.Template.Metadata.Annotations, values)
+	if err != nil {
+		return nil, err
+	}
+
+	alert.ObjectMeta = metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   t.GetNamespace(),
+		Labels:      labels,
+		Annotations: annotations,
+	}
+	if alert.Annotations == nil {
+		alert.Annotations = map[string]string{}
+	}
+	alert.Annotations[AnnotationTemplateHash] = clients.SpecHash(struct {
+		Metadata v1beta1.AlertTemplateMetadata
+		Spec     v1beta1.AlertSpec
+	}{v1beta1.AlertTemplateMetadata{Labels: labels, Annotations: annotations}, alert.Spec})
+	return alert, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alerttemplate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ptrTo[T any](v T) *T { return &v }
+
+// alertTemplate returns a template alerting on each instance's error rate.
+func alertTemplate(instances ...v1beta1.AlertTemplateInstance) *v1beta1.AlertTemplate {
+	return &v1beta1.AlertTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "errors", Namespace: "monitoring", UID: "template-uid"},
+		Spec: v1beta1.AlertTemplateSpec{
+			Parameters: []v1beta1.TemplateParameter{
+				{Name: "service"},
+				{Name: "threshold", Default: ptrTo("5")},
+			},
+			Template: v1beta1.AlertTemplateAlert{
+				Metadata: v1beta1.AlertTemplateMetadata{Labels: map[string]string{"service": "${service}"}},
+				Spec: v1beta1.AlertSpec{ForProvider: v1beta1.AlertParameters{
+					AlertName: "${service} error rate",
+					AlertType: "METRIC_BASED_ALERT",
+					Condition: v1beta1.RuleCondition{CompositeQuery: v1beta1.CompositeQuery{
+						QueryType: "promql",
+						PromQL: []v1beta1.AlertPromQuery{{
+							Name:  "A",
+							Query: `sum(rate(errors_total{service="${service}"}[5m])) > ${threshold}`,
+						}},
+					}},
+					EvalWindow: "5m",
+					Frequency:  "1m",
+					Severity:   "warning",
+					Labels:     map[string]string{"instance": "${instance}", "literal": "$${service}"},
+				}},
+			},
+			Instances: instances,
+		},
+	}
+}
+
+func TestRenderAlert(t *testing.T) {
+	tmpl := alertTemplate()
+	a, err := renderAlert(tmpl, v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}})
+	if err != nil {
+		t.Fatalf("renderAlert() error = %v", err)
+	}
+	if a.GetName() != "errors-checkout" || a.GetNamespace() != "monitoring" {
+		t.Errorf("rendered %s/%s, want monitoring/errors-checkout", a.GetNamespace(), a.GetName())
+	}
+	p := a.Spec.ForProvider
+	if p.AlertName != "checkout error rate" {
+		t.Errorf("alertName = %q", p.AlertName)
+	}
+	if q := p.Condition.CompositeQuery.PromQL[0].Query; q != `sum(rate(errors_total{service="checkout"}[5m])) > 5` {
+		t.Errorf("query = %q, want the default threshold substituted", q)
+	}
+	if p.Labels["instance"] != "checkout" || p.Labels["literal"] != "${service}" {
+		t.Errorf("labels = %v, want the built-in instance parameter and an escaped literal", p.Labels)
+	}
+	if a.GetLabels()["service"] != "checkout" {
+		t.Errorf("metadata labels = %v", a.GetLabels())
+	}
+	if tmpl.Spec.Template.Spec.ForProvider.AlertName != "${service} error rate" {
+		t.Error("rendering modified the template")
+	}
+
+	again, _ := renderAlert(tmpl, v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}})
+	other, _ := renderAlert(tmpl, v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout", "threshold": "10"}})
+	if again.GetAnnotations()[AnnotationTemplateHash] != a.GetAnnotations()[AnnotationTemplateHash] {
+		t.Error("expected the same rendering to hash the same")
+	}
+	if other.GetAnnotations()[AnnotationTemplateHash] == a.GetAnnotations()[AnnotationTemplateHash] {
+		t.Error("expected a different rendering to hash differently")
+	}
+}
+
+func TestRenderAlertErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		inst   v1beta1.AlertTemplateInstance
+		mutate func(*v1beta1.AlertTemplate)
+		want   string
+	}{
+		"MissingParameter": {
+			inst: v1beta1.AlertTemplateInstance{Name: "checkout"},
+			want: errMissingParameter + " service",
+		},
+		"UnknownParameter": {
+			inst: v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout", "team": "payments"}},
+			want: errUnknownParameter + " team",
+		},
+		"OverridesInstance": {
+			inst: v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout", "instance": "x"}},
+			want: errUnknownParameter + " instance",
+		},
+		"UndeclaredPlaceholder": {
+			inst: v1beta1.AlertTemplateInstance{Name: "checkout", Parameters: map[string]string{"service": "checkout"}},
+			mutate: func(t *v1beta1.AlertTemplate) {
+				t.Spec.Template.Spec.ForProvider.Severity = "${severity}"
+			},
+			want: errUndeclaredPlace + " severity",
+		},
+		"NameTooLong": {
+			inst: v1beta1.AlertTemplateInstance{Name: strings.Repeat("a", 250), Parameters: map[string]string{"service": "checkout"}},
+			want: "invalid Alert name",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tmpl := alertTemplate()
+			if tc.mutate != nil {
+				tc.mutate(tmpl)
+			}
+			_, err := renderAlert(tmpl, tc.inst)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("renderAlert() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,484 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/channel/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	errParseAlertmanager = "cannot parse Alertmanager configuration"
+	errWriteManifest     = "cannot write manifest"
+	errInvalidObjectName = "invalid object name"
+)
+
+// Keys of the generated Secrets.
+const (
+	keyWebhookURL = "webhookUrl"
+	keyURL        = "url"
+	keyRoutingKey = "routingKey"
+	keyServiceKey = "serviceKey"
+	keyAPIKey     = "apiKey"
+	keyAccessKey  = "accessKey"
+	keySecretKey  = "secretKey"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// AlertmanagerOptions configures ConvertAlertmanagerConfig.
+type AlertmanagerOptions struct {
+	// Namespace of the generated NotificationChannels and Secrets.
+	Namespace string
+
+	// NamePrefix is prepended to the names of the generated objects.
+	NamePrefix string
+
+	// ProviderConfigName is the ClusterProviderConfig the generated
+	// NotificationChannels use. The CRD default applies when it is empty.
+	ProviderConfigName string
+}
+
+// AlertmanagerImport holds the manifests generated from an Alertmanager
+// configuration.
+type AlertmanagerImport struct {
+	Channels []*v1beta1.NotificationChannel
+	Secrets  []*corev1.Secret
+
+	// Warnings describe the receivers, or parts of them, that could not be
+	// converted.
+	Warnings []string
+}
+
+// alertmanagerConfig mirrors the parts of alertmanager.yml that are
+// imported.
+type alertmanagerConfig struct {
+	Global    alertmanagerGlobal `json:"global,omitempty"`
+	Receivers []amReceiver       `json:"receivers"`
+}
+
+type alertmanagerGlobal struct {
+	SlackAPIURL     string `json:"slack_api_url,omitempty"`
+	SlackAPIURLFile string `json:"slack_api_url_file,omitempty"`
+	OpsGenieAPIKey  string `json:"opsgenie_api_key,omitempty"`
+}
+
+type amReceiver struct {
+	Name             string              `json:"name"`
+	SlackConfigs     []amSlackConfig     `json:"slack_configs,omitempty"`
+	WebhookConfigs   []amWebhookConfig   `json:"webhook_configs,omitempty"`
+	PagerDutyConfigs []amPagerDutyConfig `json:"pagerduty_configs,omitempty"`
+	EmailConfigs     []amEmailConfig     `json:"email_configs,omitempty"`
+	OpsGenieConfigs  []amOpsGenieConfig  `json:"opsgenie_configs,omitempty"`
+	MSTeamsConfigs   []amMSTeamsConfig   `json:"msteams_configs,omitempty"`
+	SNSConfigs       []amSNSConfig       `json:"sns_configs,omitempty"`
+}
+
+type amSlackConfig struct {
+	SendResolved *bool  `json:"send_resolved,omitempty"`
+	APIURL       string `json:"api_url,omitempty"`
+	APIURLFile   string `json:"api_url_file,omitempty"`
+	Channel      string `json:"channel,omitempty"`
+	Title        string `json:"title,omitempty"`
+}
+
+type amWebhookConfig struct {
+	SendResolved *bool  `json:"send_resolved,omitempty"`
+	URL          string `json:"url,omitempty"`
+	URLFile      string `json:"url_file,omitempty"`
+	MaxAlerts    *int   `json:"max_alerts,omitempty"`
+}
+
+type amPagerDutyConfig struct {
+	SendResolved   *bool  `json:"send_resolved,omitempty"`
+	RoutingKey     string `json:"routing_key,omitempty"`
+	RoutingKeyFile string `json:"routing_key_file,omitempty"`
+	ServiceKey     string `json:"service_key,omitempty"`
+	ServiceKeyFile string `json:"service_key_file,omitempty"`
+	Severity       string `json:"severity,omitempty"`
+}
+
+type amEmailConfig struct {
+	SendResolved *bool  `json:"send_resolved,omitempty"`
+	To           string `json:"to,omitempty"`
+}
+
+type amOpsGenieConfig struct {
+	SendResolved *bool  `json:"send_resolved,omitempty"`
+	APIKey       string `json:"api_key,omitempty"`
+	APIKeyFile   string `json:"api_key_file,omitempty"`
+	Priority     string `json:"priority,omitempty"`
+}
+
+type amMSTeamsConfig struct {
+	SendResolved   *bool  `json:"send_resolved,omitempty"`
+	WebhookURL     string `json:"webhook_url,omitempty"`
+	WebhookURLFile string `json:"webhook_url_file,omitempty"`
+	Title          string `json:"title,omitempty"`
+}
+
+type amSNSConfig struct {
+	SendResolved *bool  `json:"send_resolved,omitempty"`
+	TopicARN     string `json:"topic_arn,omitempty"`
+	SigV4        struct {
+		Region    string `json:"region,omitempty"`
+		AccessKey string `json:"access_key,omitempty"`
+		SecretKey string `json:"secret_key,omitempty"`
+	} `json:"sigv4,omitempty"`
+}
+
+// supportedReceiverKeys are the receiver fields that are imported.
+var supportedReceiverKeys = map[string]bool{
+	"name":              true,
+	"slack_configs":     true,
+	"webhook_configs":   true,
+	"pagerduty_configs": true,
+	"email_configs":     true,
+	"opsgenie_configs":  true,
+	"msteams_configs":   true,
+	"sns_configs":       true,
+}
+
+// Alertmanager's send_resolved defaults, which are made explicit so the
+// imported channels behave the same.
+const (
+	slackSendResolved     = false
+	webhookSendResolved   = true
+	pagerDutySendResolved = true
+	emailSendResolved     = false
+	opsGenieSendResolved  = true
+	msTeamsSendResolved   = true
+	snsSendResolved       = true
+)
+
+// ConvertAlertmanagerConfig converts the receivers of an alertmanager.yml
+// into NotificationChannels. A receiver with a single integration becomes
+// one channel named after it; one with several becomes a channel per
+// integration, suffixed with its type. Inline credentials and webhook URLs
+// are moved to a Secret per channel and referenced through the channel's
+// SecretRef fields. Integrations that can't be imported, such as those
+// reading credentials from files, are reported as warnings.
+func ConvertAlertmanagerConfig(data []byte, o AlertmanagerOptions) (*AlertmanagerImport, error) {
+	cfg := alertmanagerConfig{}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, errParseAlertmanager)
+	}
+	raw := struct {
+		Receivers []map[string]interface{} `json:"receivers"`
+	}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, errors.Wrap(err, errParseAlertmanager)
+	}
+
+	c := &amConverter{opts: o, global: cfg.Global, out: &AlertmanagerImport{}, names: map[string]bool{}}
+	for i, r := range cfg.Receivers {
+		var unsupported []string
+		if i < len(raw.Receivers) {
+			for k := range raw.Receivers[i] {
+				if !supportedReceiverKeys[k] {
+					unsupported = append(unsupported, k)
+				}
+			}
+		}
+		sort.Strings(unsupported)
+		for _, k := range unsupported {
+			c.warn(r.Name, "%s are not supported by SigNoz and were skipped", k)
+		}
+		c.receiver(r)
+	}
+	return c.out, nil
+}
+
+type amConverter struct {
+	opts   AlertmanagerOptions
+	global alertmanagerGlobal
+	out    *AlertmanagerImport
+	names  map[string]bool
+}
+
+// An integration is one converted receiver config. Its credentials are
+// kept apart from its parameters until the Secret holding them is named.
+type integration struct {
+	kind    string
+	secrets map[string]string
+	apply   func(p *v1beta1.NotificationChannelParameters, ref secretRef)
+}
+
+// A secretRef returns a reference to key of the generated Secret, or nil
+// if the integration has no such credential.
+type secretRef func(key string) *xpv1.SecretKeySelector
+
+func (c *amConverter) warn(receiver, format string, args ...interface{}) {
+	c.out.Warnings = append(c.out.Warnings, fmt.Sprintf("receiver %s: %s", receiver, fmt.Sprintf(format, args...)))
+}
+
+func (c *amConverter) receiver(r amReceiver) {
+	var ints []integration
+	for i, s := range r.SlackConfigs {
+		url, file := s.APIURL, s.APIURLFile
+		if url == "" && file == "" {
+			url, file = c.global.SlackAPIURL, c.global.SlackAPIURLFile
+		}
+		if file != "" {
+			c.warn(r.Name, "slack_configs[%d] reads api_url_file %s, which can't be imported", i, file)
+			continue
+		}
+		ints = append(ints, integration{kind: "slack", secrets: secrets(keyWebhookURL, url),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.SlackConfigs = []v1beta1.SlackConfig{{
+					Channel:             s.Channel,
+					WebhookURLSecretRef: ref(keyWebhookURL),
+					Title:               optional(s.Title),
+					SendResolved:        sendResolved(s.SendResolved, slackSendResolved),
+				}}
+			}})
+	}
+	for i, w := range r.WebhookConfigs {
+		if w.URLFile != "" {
+			c.warn(r.Name, "webhook_configs[%d] reads url_file %s, which can't be imported", i, w.URLFile)
+			continue
+		}
+		ints = append(ints, integration{kind: "webhook", secrets: secrets(keyURL, w.URL),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.WebhookConfigs = []v1beta1.WebhookConfig{{
+					URLSecretRef: ref(keyURL),
+					MaxAlerts:    w.MaxAlerts,
+					SendResolved: sendResolved(w.SendResolved, webhookSendResolved),
+				}}
+			}})
+	}
+	for i, d := range r.PagerDutyConfigs {
+		if d.RoutingKeyFile != "" || d.ServiceKeyFile != "" {
+			c.warn(r.Name, "pagerduty_configs[%d] reads its key from a file, which can't be imported", i)
+			continue
+		}
+		ints = append(ints, integration{kind: "pagerduty", secrets: secrets(keyRoutingKey, d.RoutingKey, keyServiceKey, d.ServiceKey),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.PagerDutyConfigs = []v1beta1.PagerDutyConfig{{
+					RoutingKeySecretRef: ref(keyRoutingKey),
+					ServiceKeySecretRef: ref(keyServiceKey),
+					Severity:            optional(d.Severity),
+					SendResolved:        sendResolved(d.SendResolved, pagerDutySendResolved),
+				}}
+			}})
+	}
+	for i, e := range r.EmailConfigs {
+		var to []string
+		for _, addr := range strings.Split(e.To, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				to = append(to, addr)
+			}
+		}
+		if len(to) == 0 {
+			c.warn(r.Name, "email_configs[%d] has no recipients", i)
+			continue
+		}
+		ints = append(ints, integration{kind: "email",
+			apply: func(p *v1beta1.NotificationChannelParameters, _ secretRef) {
+				p.EmailConfigs = []v1beta1.EmailConfig{{To: to, SendResolved: sendResolved(e.SendResolved, emailSendResolved)}}
+			}})
+	}
+	for i, g := range r.OpsGenieConfigs {
+		if g.APIKeyFile != "" {
+			c.warn(r.Name, "opsgenie_configs[%d] reads api_key_file %s, which can't be imported", i, g.APIKeyFile)
+			continue
+		}
+		key := g.APIKey
+		if key == "" {
+			key = c.global.OpsGenieAPIKey
+		}
+		ints = append(ints, integration{kind: "opsgenie", secrets: secrets(keyAPIKey, key),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.OpsGenieConfigs = []v1beta1.OpsGenieConfig{{
+					APIKeySecretRef: ref(keyAPIKey),
+					Priority:        optional(g.Priority),
+					SendResolved:    sendResolved(g.SendResolved, opsGenieSendResolved),
+				}}
+			}})
+	}
+	for i, m := range r.MSTeamsConfigs {
+		if m.WebhookURLFile != "" {
+			c.warn(r.Name, "msteams_configs[%d] reads webhook_url_file %s, which can't be imported", i, m.WebhookURLFile)
+			continue
+		}
+		ints = append(ints, integration{kind: "msteams", secrets: secrets(keyWebhookURL, m.WebhookURL),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.MSTeamsConfigs = []v1beta1.MSTeamsConfig{{
+					WebhookURLSecretRef: ref(keyWebhookURL),
+					Title:               optional(m.Title),
+					SendResolved:        sendResolved(m.SendResolved, msTeamsSendResolved),
+				}}
+			}})
+	}
+	for i, n := range r.SNSConfigs {
+		if n.TopicARN == "" {
+			c.warn(r.Name, "sns_configs[%d] does not publish to a topic_arn, which is all SigNoz supports", i)
+			continue
+		}
+		region := n.SigV4.Region
+		if parts := strings.Split(n.TopicARN, ":"); region == "" && len(parts) > 3 {
+			// arn:aws:sns:<region>:<account>:<topic>
+			region = parts[3]
+		}
+		ints = append(ints, integration{kind: "sns", secrets: secrets(keyAccessKey, n.SigV4.AccessKey, keySecretKey, n.SigV4.SecretKey),
+			apply: func(p *v1beta1.NotificationChannelParameters, ref secretRef) {
+				p.SNSConfigs = []v1beta1.SNSConfig{{
+					TopicARN:           n.TopicARN,
+					Region:             region,
+					AccessKeySecretRef: ref(keyAccessKey),
+					SecretKeySecretRef: ref(keySecretKey),
+					SendResolved:       sendResolved(n.SendResolved, snsSendResolved),
+				}}
+			}})
+	}
+
+	if len(ints) == 0 {
+		c.warn(r.Name, "no integrations could be imported")
+		return
+	}
+	counts := map[string]int{}
+	for _, in := range ints {
+		counts[in.kind]++
+	}
+	seen := map[string]int{}
+	for _, in := range ints {
+		// Name channels after their receiver, disambiguating by type and
+		// then by position when a receiver has several integrations.
+		name := r.Name
+		if len(ints) > 1 {
+			name += "-" + in.kind
+			if seen[in.kind]++; counts[in.kind] > 1 {
+				name = fmt.Sprintf("%s-%d", name, seen[in.kind])
+			}
+		}
+		c.emit(r.Name, name, in)
+	}
+}
+
+// emit adds the NotificationChannel, and Secret if it has credentials, of
+// an integration.
+func (c *amConverter) emit(receiver, name string, in integration) {
+	objName := objectName(c.opts.NamePrefix + name)
+	if errs := validation.IsDNS1123Subdomain(objName); len(errs) > 0 {
+		c.warn(receiver, "%s %q: %s", errInvalidObjectName, objName, strings.Join(errs, ", "))
+		return
+	}
+	if c.names[objName] {
+		c.warn(receiver, "channel %s was already generated by another receiver and was skipped", objName)
+		return
+	}
+	c.names[objName] = true
+
+	secretName := objName + "-credentials"
+	params := v1beta1.NotificationChannelParameters{Name: name, Type: in.kind}
+	in.apply(&params, func(key string) *xpv1.SecretKeySelector {
+		if _, ok := in.secrets[key]; !ok {
+			return nil
+		}
+		return &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: secretName, Namespace: c.opts.Namespace}, Key: key}
+	})
+	if len(in.secrets) > 0 {
+		c.out.Secrets = append(c.out.Secrets, &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Name: secretName, Namespace: c.opts.Namespace},
+			Type:       corev1.SecretTypeOpaque,
+			StringData: in.secrets,
+		})
+	}
+
+	nc := &v1beta1.NotificationChannel{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: v1beta1.NotificationChannel_Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: objName, Namespace: c.opts.Namespace},
+		Spec:       v1beta1.NotificationChannelSpec{ForProvider: params},
+	}
+	if c.opts.ProviderConfigName != "" {
+		nc.Spec.ProviderConfigReference = &xpv1.ProviderConfigReference{Kind: "ClusterProviderConfig", Name: c.opts.ProviderConfigName}
+	}
+	c.out.Channels = append(c.out.Channels, nc)
+}
+
+// WriteManifests writes the generated Secrets and NotificationChannels to w
+// as a multi-document YAML stream.
+func (i *AlertmanagerImport) WriteManifests(w io.Writer) error {
+	objs := make([]runtime.Object, 0, len(i.Secrets)+len(i.Channels))
+	for _, s := range i.Secrets {
+		objs = append(objs, s)
+	}
+	for _, c := range i.Channels {
+		objs = append(objs, c)
+	}
+	for _, o := range objs {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+		if err != nil {
+			return errors.Wrap(err, errWriteManifest)
+		}
+		delete(u, "status")
+		if md, ok := u["metadata"].(map[string]interface{}); ok {
+			delete(md, "creationTimestamp")
+		}
+		b, err := yaml.Marshal(u)
+		if err != nil {
+			return errors.Wrap(err, errWriteManifest)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", b); err != nil {
+			return errors.Wrap(err, errWriteManifest)
+		}
+	}
+	return nil
+}
+
+// objectName turns a receiver name into a DNS subdomain.
+func objectName(s string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+// secrets returns the non-empty values of alternating keys and values.
+func secrets(kv ...string) map[string]string {
+	out := map[string]string{}
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			out[kv[i]] = kv[i+1]
+		}
+	}
+	return out
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func sendResolved(v *bool, def bool) *bool {
+	if v != nil {
+		return v
+	}
+	return &def
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package channel
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/channel/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const alertmanagerYAML = `
+global:
+  slack_api_url: https://hooks.slack.com/services/T000/B000/global
+  opsgenie_api_key: global-opsgenie-key
+route:
+  receiver: team-a
+receivers:
+  - name: team-a
+    slack_configs:
+      - channel: '#alerts'
+        title: '{{ .CommonLabels.alertname }}'
+      - channel: '#alerts-critical'
+        api_url: https://hooks.slack.com/services/T000/B000/critical
+        send_resolved: true
+    email_configs:
+      - to: 'a@example.com, b@example.com'
+  - name: Pager_Duty
+    pagerduty_configs:
+      - routing_key: pd-routing-key
+        severity: critical
+  - name: hooks
+    webhook_configs:
+      - url: https://example.com/hook?token=abc
+        max_alerts: 10
+  - name: ops
+    opsgenie_configs:
+      - priority: P1
+  - name: teams
+    msteams_configs:
+      - webhook_url_file: /etc/alertmanager/teams-url
+  - name: aws
+    sns_configs:
+      - topic_arn: arn:aws:sns:eu-west-1:123456789012:alerts
+        sigv4:
+          access_key: AKIA123
+          secret_key: s3cr3t
+  - name: chat
+    telegram_configs:
+      - chat_id: 1
+  - name: blackhole
+`
+
+func TestConvertAlertmanagerConfig(t *testing.T) {
+	got, err := ConvertAlertmanagerConfig([]byte(alertmanagerYAML), AlertmanagerOptions{Namespace: "monitoring", NamePrefix: "am-", ProviderConfigName: "signoz"})
+	if err != nil {
+		t.Fatalf("ConvertAlertmanagerConfig() error = %v", err)
+	}
+
+	channels := map[string]*v1beta1.NotificationChannel{}
+	for _, c := range got.Channels {
+		channels[c.GetName()] = c
+	}
+	secrets := map[string]*corev1.Secret{}
+	for _, s := range got.Secrets {
+		secrets[s.GetName()] = s
+	}
+	want := []string{"am-team-a-slack-1", "am-team-a-slack-2", "am-team-a-email", "am-pager-duty", "am-hooks", "am-ops", "am-aws"}
+	if len(channels) != len(want) {
+		t.Errorf("channels = %v, want %v", len(channels), want)
+	}
+	for _, name := range want {
+		c, ok := channels[name]
+		if !ok {
+			t.Errorf("missing channel %s", name)
+			continue
+		}
+		if c.GetNamespace() != "monitoring" || c.Spec.ProviderConfigReference == nil || c.Spec.ProviderConfigReference.Name != "signoz" {
+			t.Errorf("channel %s namespace, providerConfigRef = %s, %+v", name, c.GetNamespace(), c.Spec.ProviderConfigReference)
+		}
+	}
+
+	// A slack config without api_url falls back to the global one, and
+	// webhook URLs are moved to a Secret rather than left in the spec.
+	slack := channels["am-team-a-slack-1"].Spec.ForProvider
+	if slack.Name != "team-a-slack-1" || slack.Type != "slack" || slack.SlackConfigs[0].Channel != "#alerts" {
+		t.Errorf("slack channel = %+v", slack)
+	}
+	ref := slack.SlackConfigs[0].WebhookURLSecretRef
+	if slack.SlackConfigs[0].WebhookURL != nil || ref == nil || ref.Name != "am-team-a-slack-1-credentials" || ref.Namespace != "monitoring" {
+		t.Fatalf("slack webhook URL = %v, ref = %+v, want a Secret reference", slack.SlackConfigs[0].WebhookURL, ref)
+	}
+	if v := secrets[ref.Name].StringData[ref.Key]; v != "https://hooks.slack.com/services/T000/B000/global" {
+		t.Errorf("slack Secret %s = %q, want the global api_url", ref.Key, v)
+	}
+	if sr := slack.SlackConfigs[0].SendResolved; sr == nil || *sr {
+		t.Errorf("slack send_resolved = %v, want Alertmanager's default of false", sr)
+	}
+
+	email := channels["am-team-a-email"].Spec.ForProvider.EmailConfigs[0]
+	if len(email.To) != 2 || email.To[1] != "b@example.com" {
+		t.Errorf("email to = %v", email.To)
+	}
+	if _, ok := secrets["am-team-a-email-credentials"]; ok {
+		t.Error("email channel without credentials got a Secret")
+	}
+
+	pd := channels["am-pager-duty"].Spec.ForProvider.PagerDutyConfigs[0]
+	if pd.RoutingKeySecretRef == nil || pd.ServiceKeySecretRef != nil || *pd.Severity != "critical" {
+		t.Errorf("pagerduty config = %+v", pd)
+	}
+	if v := secrets["am-pager-duty-credentials"].StringData[pd.RoutingKeySecretRef.Key]; v != "pd-routing-key" {
+		t.Errorf("pagerduty routing key = %q", v)
+	}
+
+	hook := channels["am-hooks"].Spec.ForProvider.WebhookConfigs[0]
+	if hook.URL != nil || hook.URLSecretRef == nil || *hook.MaxAlerts != 10 || !*hook.SendResolved {
+		t.Errorf("webhook config = %+v", hook)
+	}
+
+	if v := secrets["am-ops-credentials"].StringData[keyAPIKey]; v != "global-opsgenie-key" {
+		t.Errorf("opsgenie api key = %q, want the global one", v)
+	}
+
+	sns := channels["am-aws"].Spec.ForProvider.SNSConfigs[0]
+	if sns.Region != "eu-west-1" || sns.AccessKeySecretRef.Key != keyAccessKey || sns.SecretKeySecretRef.Key != keySecretKey {
+		t.Errorf("sns config = %+v", sns)
+	}
+
+	// Unconvertible integrations and receivers are reported.
+	warnings := strings.Join(got.Warnings, "\n")
+	for _, w := range []string{
+		"receiver teams: msteams_configs[0] reads webhook_url_file",
+		"receiver chat: telegram_configs are not supported",
+		"receiver blackhole: no integrations could be imported",
+	} {
+		if !strings.Contains(warnings, w) {
+			t.Errorf("warnings missing %q:\n%s", w, warnings)
+		}
+	}
+}
+
+func TestWriteManifests(t *testing.T) {
+	got, err := ConvertAlertmanagerConfig([]byte(alertmanagerYAML), AlertmanagerOptions{Namespace: "monitoring"})
+	if err != nil {
+		t.Fatalf("ConvertAlertmanagerConfig() error = %v", err)
+	}
+	buf := &bytes.Buffer{}
+	if err := got.WriteManifests(buf); err != nil {
+		t.Fatalf("WriteManifests() error = %v", err)
+	}
+
+	docs := strings.Split(strings.TrimPrefix(buf.String(), "---\n"), "---\n")
+	if len(docs) != len(got.Secrets)+len(got.Channels) {
+		t.Fatalf("%d documents, want %d", len(docs), len(got.Secrets)+len(got.Channels))
+	}
+	last := &v1beta1.NotificationChannel{}
+	if err := yaml.UnmarshalStrict([]byte(docs[len(docs)-1]), last); err != nil {
+		t.Fatalf("cannot read back manifest: %v", err)
+	}
+	if last.Kind != v1beta1.NotificationChannel_Kind || last.GetName() != "aws" {
+		t.Errorf("last manifest = %s %s, want NotificationChannel aws", last.Kind, last.GetName())
+	}
+	if strings.Contains(buf.String(), "status:") || strings.Contains(buf.String(), "creationTimestamp") {
+		t.Errorf("manifests include server-set fields:\n%s", buf.String())
+	}
+}
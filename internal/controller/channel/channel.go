@@ -18,7 +18,11 @@ package channel
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
@@ -58,16 +62,18 @@ const (
 // Setup adds a controller that reconciles NotificationChannel managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.NotificationChannel_GroupVersionKind.Kind)
+	recorder := clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
 
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
 			kube:         resource.ClientApplicator{Client: mgr.GetClient(), Applicator: resource.NewAPIPatchingApplicator(mgr.GetClient())},
 			usage:        resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-			newServiceFn: clients.NewClient,
+			newServiceFn: clients.CachedClient,
+			recorder:     recorder,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features != nil && o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -84,7 +90,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1beta1.NotificationChannel{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, clients.NewBackoffReconciler(r, clients.DefaultBackoffPolicy), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -93,6 +99,7 @@ type connector struct {
 	kube         resource.ClientApplicator
 	usage        resource.ModernTracker
 	newServiceFn func(cfg clients.Config) *clients.Client
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -134,16 +141,18 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	log.V(1).Info("Connect: GetConfig succeeded", "baseURL", cfg.BaseURL)
 
 	return &external{
-		service: c.newServiceFn(*cfg),
-		kube:    c.kube.Client,
+		service:  c.newServiceFn(*cfg),
+		kube:     c.kube.Client,
+		recorder: c.recorder,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *clients.Client
-	kube    client.Client
+	service  *clients.Client
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -210,11 +219,26 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	// Set Ready condition since the resource exists
 	cr.Status.SetConditions(xpv1.Available())
 
-	// Check if the channel is up to date
-	upToDate, err := c.isChannelUpToDate(ctx, cr.Spec.ForProvider, channel)
+	// Check if the channel is up to date. The payload carries resolved
+	// secrets, so a rotated Secret changes its hash.
+	channelData, err := c.convertToChannelData(ctx, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	check := clients.DriftCheck{
+		SpecHash: clients.SpecHash(cr.Spec.ForProvider),
+		Payload:  channelData,
+		Observed: channel,
+		Policy:   cr.Spec.ExternalChangePolicy,
+		Diff:     func() clients.Diff { return channelDiff(channelData, channel) },
+	}
+	upToDate, drift, err := check.UpToDate(ctx, c.kube, c.recorder, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	if drift != nil {
+		cr.Status.AtProvider.LastDrift = drift
+	}
 
 	return managed.ExternalObservation{
 		ResourceExists:   true,
@@ -272,6 +296,11 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
 
+	if err := clients.RecordApplied(ctx, c.kube, cr, clients.SpecHash(cr.Spec.ForProvider)); err != nil {
+		// The channel was updated; a missed count only delays loop detection.
+		log.FromContext(ctx).Info("Cannot record applied spec", "error", err)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -303,18 +332,66 @@ func (c *external) Disconnect(ctx context.Context) error {
 
 // Helper functions
 
-func (c *external) isChannelUpToDate(ctx context.Context, spec v1beta1.NotificationChannelParameters, channel *clients.ChannelData) (bool, error) {
-	if spec.Name != channel.Name {
-		return false, nil
+// channelDiff compares the payload Create or Update would send with the
+// channel SigNoz returned: its name, its type, and the fields set in the
+// configs of its type. Fields SigNoz adds, such as defaults, are ignored.
+func channelDiff(desired, observed *clients.ChannelData) clients.Diff {
+	var d clients.Diff
+	if desired.Name != observed.Name {
+		d.Add("name", desired.Name, observed.Name)
+	}
+	if desired.Type != observed.Type {
+		d.Add("type", desired.Type, observed.Type)
+		return d
+	}
+
+	key := desired.Type + "_configs"
+	want, got := channelConfigs(desired)[key], channelConfigs(observed)[key]
+	for i, w := range want {
+		path := clients.IndexPath(key, i)
+		wm, _ := w.(map[string]interface{})
+		if i >= len(got) {
+			d.Add(path, w, nil)
+			continue
+		}
+		gm, _ := got[i].(map[string]interface{})
+		fields := make([]string, 0, len(wm))
+		for k := range wm {
+			fields = append(fields, k)
+		}
+		sort.Strings(fields)
+		for _, k := range fields {
+			if gv, ok := gm[k]; !ok || !reflect.DeepEqual(wm[k], gv) {
+				d.Add(clients.JoinPath(path, k), wm[k], gm[k])
+			}
+		}
 	}
+	return d
+}
 
-	if spec.Type != channel.Type {
-		return false, nil
+// channelConfigs returns the receiver configs of a channel by key, such as
+// slack_configs, in their JSON form. SigNoz returns them in the channel's
+// data, a JSON encoded receiver, rather than alongside its name.
+func channelConfigs(c *clients.ChannelData) map[string][]interface{} {
+	out := map[string][]interface{}{}
+	collect := func(raw []byte) {
+		var v map[string]interface{}
+		if json.Unmarshal(raw, &v) != nil {
+			return
+		}
+		for k, e := range v {
+			if l, ok := e.([]interface{}); ok && strings.HasSuffix(k, "_configs") && out[k] == nil {
+				out[k] = l
+			}
+		}
 	}
-
-	// For simplicity, we'll consider the channel up to date if basic fields match
-	// In a more sophisticated implementation, we would deeply compare the channel data
-	return true, nil
+	if c.Data != "" {
+		collect([]byte(c.Data))
+	}
+	if raw, err := json.Marshal(c); err == nil {
+		collect(raw)
+	}
+	return out
 }
 
 func (c *external) convertToChannelData(ctx context.Context, spec v1beta1.NotificationChannelParameters) (*clients.ChannelData, error) {
@@ -18,9 +18,20 @@ package channel
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 	"github.com/rossigee/provider-signoz/apis/channel/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestConvertToChannelData(t *testing.T) {
@@ -179,6 +190,118 @@ func TestUnsupportedChannelType(t *testing.T) {
 	}
 }
 
+func TestChannelDiff(t *testing.T) {
+	desired := &clients.ChannelData{
+		Name: "Oncall",
+		Type: "webhook",
+		WebhookConfigs: []interface{}{map[string]interface{}{
+			"url":           "https://hooks.example.com/oncall",
+			"send_resolved": true,
+			"max_alerts":    int32(5),
+		}},
+	}
+
+	// SigNoz returns the receiver JSON encoded in data, with defaults set.
+	inSync := &clients.ChannelData{
+		Name: "Oncall",
+		Type: "webhook",
+		Data: `{"name":"Oncall","webhook_configs":[{"url":"https://hooks.example.com/oncall","send_resolved":true,"max_alerts":5,"http_config":{}}]}`,
+	}
+	if d := channelDiff(desired, inSync); !d.Empty() {
+		t.Errorf("Expected no drift, got %s", d)
+	}
+
+	d := channelDiff(desired, &clients.ChannelData{Name: "oncall", Type: "webhook", WebhookConfigs: desired.WebhookConfigs})
+	if len(d) != 1 || d[0].Path != "name" || d[0].Desired != `"Oncall"` || d[0].Observed != `"oncall"` {
+		t.Errorf("Expected a single name difference, got %s", d)
+	}
+
+	drifted := &clients.ChannelData{
+		Name: "Oncall",
+		Type: "webhook",
+		Data: `{"webhook_configs":[{"url":"https://hooks.example.com/other","send_resolved":false,"max_alerts":5}]}`,
+	}
+	d = channelDiff(desired, drifted)
+	if len(d) != 2 || d[0].Path != "webhook_configs[0].send_resolved" || d[1].Path != "webhook_configs[0].url" || d[1].Desired == `"https://hooks.example.com/oncall"` {
+		t.Errorf("Expected redacted send_resolved and url differences, got %s", d)
+	}
+
+	if d := channelDiff(desired, &clients.ChannelData{Name: "Oncall", Type: "webhook"}); len(d) != 1 || d[0].Path != "webhook_configs[0]" {
+		t.Errorf("Expected a missing config, got %s", d)
+	}
+}
+
+func TestObserveRotatedSecret(t *testing.T) {
+	const id = "0b6a2c8e-5f1d-4c3e-9a7b-2d4e6f8a0c1e"
+	var mu sync.Mutex
+	served := "https://hooks.example.com/old"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			var body clients.ChannelData
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			served, _ = body.WebhookConfigs[0].(map[string]interface{})["url"].(string)
+		}
+		data, _ := json.Marshal(map[string]interface{}{"webhook_configs": []interface{}{map[string]interface{}{"url": served}}})
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": "success",
+			"data":   map[string]interface{}{"id": id, "name": "Oncall", "type": "webhook", "data": string(data)},
+		})
+	}))
+	defer ts.Close()
+
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add core types to scheme: %v", err)
+	}
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add channel types to scheme: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oncall", Namespace: "monitoring"},
+		Data:       map[string][]byte{"url": []byte("https://hooks.example.com/old")},
+	}
+	cr := &v1beta1.NotificationChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "oncall", Namespace: "monitoring", UID: "uid"},
+		Spec: v1beta1.NotificationChannelSpec{ForProvider: v1beta1.NotificationChannelParameters{
+			Name: "Oncall",
+			Type: "webhook",
+			WebhookConfigs: []v1beta1.WebhookConfig{{
+				URLSecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "oncall", Namespace: "monitoring"}, Key: "url"},
+			}},
+		}},
+	}
+	meta.SetExternalName(cr, id)
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(secret, cr).Build()
+	e := &external{service: clients.NewClient(clients.Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"}), kube: kube}
+	ctx := context.Background()
+
+	obs, err := e.Observe(ctx, cr)
+	if err != nil || !obs.ResourceUpToDate {
+		t.Fatalf("Observe() of an in-sync channel = %+v, %v", obs, err)
+	}
+	if obs, err = e.Observe(ctx, cr); err != nil || !obs.ResourceUpToDate {
+		t.Fatalf("second Observe() = %+v, %v", obs, err)
+	}
+
+	secret.Data["url"] = []byte("https://hooks.example.com/new")
+	if err := kube.Update(ctx, secret); err != nil {
+		t.Fatalf("cannot rotate secret: %v", err)
+	}
+	if obs, err = e.Observe(ctx, cr); err != nil || obs.ResourceUpToDate {
+		t.Fatalf("Observe() after rotating the Secret = %+v, %v, want an update", obs, err)
+	}
+	if _, err := e.Update(ctx, cr); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if served != "https://hooks.example.com/new" {
+		t.Errorf("SigNoz has url %q, want the rotated one", served)
+	}
+}
+
 func stringPtr(s string) *string {
 	return &s
 }
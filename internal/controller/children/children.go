@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package children writes and prunes the resources a controller generates
+// for an owner, such as the Alerts of an AlertTemplate.
+package children
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	errGet           = "cannot get generated resource"
+	errCreate        = "cannot create generated resource"
+	errUpdate        = "cannot update generated resource"
+	errList          = "cannot list generated resources"
+	errDelete        = "cannot delete generated resource"
+	errNotControlled = "exists and is not controlled by its owner"
+)
+
+// ErrNotControlled is returned by Apply for a resource of the desired name
+// that the owner doesn't control. Retrying won't help: someone has to
+// delete or rename it.
+var ErrNotControlled = errors.New(errNotControlled)
+
+// Apply creates desired, or updates the existing resource of its name if
+// the hash annotation differs, copying desired's spec with copySpec.
+// desired must be controlled by owner; existing must be an empty object of
+// its type, and holds the resource as last read or written. Apply returns
+// true if it created the resource.
+func Apply(ctx context.Context, kube client.Client, owner metav1.Object, hashAnnotation string, desired, existing client.Object, copySpec func()) (bool, error) {
+	err := kube.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	switch {
+	case kerrors.IsNotFound(err):
+		return true, errors.Wrap(kube.Create(ctx, desired), errCreate)
+	case err != nil:
+		return false, errors.Wrap(err, errGet)
+	}
+
+	if !metav1.IsControlledBy(existing, owner) {
+		return false, errors.Wrap(ErrNotControlled, desired.GetName())
+	}
+	if existing.GetAnnotations()[hashAnnotation] == desired.GetAnnotations()[hashAnnotation] {
+		return false, nil
+	}
+	// Merge metadata so the annotations the managed reconciler keeps on the
+	// resource, such as its external name, survive.
+	existing.SetLabels(merge(existing.GetLabels(), desired.GetLabels()))
+	existing.SetAnnotations(merge(existing.GetAnnotations(), desired.GetAnnotations()))
+	copySpec()
+	return false, errors.Wrap(kube.Update(ctx, existing), errUpdate)
+}
+
+// Prune deletes the resources of list, listed with opts, that owner
+// controls and that aren't kept.
+func Prune(ctx context.Context, kube client.Client, owner metav1.Object, list client.ObjectList, keep map[string]bool, opts ...client.ListOption) error {
+	if err := kube.List(ctx, list, opts...); err != nil {
+		return errors.Wrap(err, errList)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return errors.Wrap(err, errList)
+	}
+	for _, item := range items {
+		o, ok := item.(client.Object)
+		if !ok || keep[o.GetName()] || !metav1.IsControlledBy(o, owner) || o.GetDeletionTimestamp() != nil {
+			continue
+		}
+		if err := kube.Delete(ctx, o); client.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errDelete)
+		}
+		log.FromContext(ctx).Info("Deleted resource no longer generated", "name", o.GetName())
+	}
+	return nil
+}
+
+// merge returns base with the entries of overlay set.
+func merge(base, overlay map[string]string) map[string]string {
+	if len(overlay) == 0 {
+		return base
+	}
+	out := make(map[string]string, len(base)+len(overlay))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overlay {
+		out[k] = v
+	}
+	return out
+}
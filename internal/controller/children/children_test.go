@@ -0,0 +1,107 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package children
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const hashAnnotation = "example.org/hash"
+
+func alert(owner *v1beta1.AlertTemplate, name, hash, query string) *v1beta1.Alert {
+	a := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   "monitoring",
+		Labels:      map[string]string{"owner": owner.GetName()},
+		Annotations: map[string]string{hashAnnotation: hash},
+	}}
+	a.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(owner, v1beta1.AlertTemplate_GroupVersionKind)})
+	a.Spec.ForProvider.AlertName = query
+	return a
+}
+
+func TestApplyAndPrune(t *testing.T) {
+	ctx := context.Background()
+	s := runtime.NewScheme()
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add alert types to scheme: %v", err)
+	}
+	owner := &v1beta1.AlertTemplate{ObjectMeta: metav1.ObjectMeta{Name: "errors", Namespace: "monitoring", UID: "uid"}}
+	unowned := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "unowned", Namespace: "monitoring", Labels: map[string]string{"owner": "errors"}}}
+	kube := fake.NewClientBuilder().WithScheme(s).WithObjects(unowned).Build()
+	apply := func(desired *v1beta1.Alert) (*v1beta1.Alert, bool, error) {
+		t.Helper()
+		existing := &v1beta1.Alert{}
+		created, err := Apply(ctx, kube, owner, hashAnnotation, desired, existing, func() { existing.Spec = desired.Spec })
+		return existing, created, err
+	}
+
+	if _, created, err := apply(alert(owner, "a", "1", "first")); err != nil || !created {
+		t.Fatalf("Apply() of a new resource = %v, %v, want created", created, err)
+	}
+
+	// Updates keep the metadata the managed reconciler adds.
+	got := &v1beta1.Alert{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "a"}, got); err != nil {
+		t.Fatalf("cannot get resource: %v", err)
+	}
+	meta.SetExternalName(got, "42")
+	if err := kube.Update(ctx, got); err != nil {
+		t.Fatalf("cannot set external name: %v", err)
+	}
+	existing, created, err := apply(alert(owner, "a", "2", "second"))
+	if err != nil || created {
+		t.Fatalf("Apply() of a changed resource = %v, %v, want updated", created, err)
+	}
+	if existing.Spec.ForProvider.AlertName != "second" || meta.GetExternalName(existing) != "42" {
+		t.Errorf("updated resource = %q with external name %q", existing.Spec.ForProvider.AlertName, meta.GetExternalName(existing))
+	}
+
+	// Resources whose hash is unchanged aren't written.
+	if existing, _, err = apply(alert(owner, "a", "2", "ignored")); err != nil || existing.Spec.ForProvider.AlertName != "second" {
+		t.Errorf("Apply() of an unchanged hash = %q, %v", existing.Spec.ForProvider.AlertName, err)
+	}
+
+	if _, _, err := apply(alert(owner, "unowned", "1", "x")); !errors.Is(err, ErrNotControlled) {
+		t.Errorf("Apply() of an uncontrolled resource error = %v, want ErrNotControlled", err)
+	}
+
+	// Pruning leaves kept and uncontrolled resources alone.
+	if _, _, err := apply(alert(owner, "b", "1", "stale")); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := Prune(ctx, kube, owner, &v1beta1.AlertList{}, map[string]bool{"a": true}, client.MatchingLabels{"owner": "errors"}); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	for name, want := range map[string]bool{"a": true, "b": false, "unowned": true} {
+		err := kube.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: name}, &v1beta1.Alert{})
+		if exists := !kerrors.IsNotFound(err); exists != want {
+			t.Errorf("%s exists = %v, want %v", name, exists, want)
+		}
+	}
+}
@@ -20,9 +20,12 @@ package controller
 import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/rossigee/provider-signoz/internal/controller/alert"
+	"github.com/rossigee/provider-signoz/internal/controller/alerttemplate"
 	"github.com/rossigee/provider-signoz/internal/controller/channel"
 	"github.com/rossigee/provider-signoz/internal/controller/dashboard"
+	"github.com/rossigee/provider-signoz/internal/controller/plannedmaintenance"
 	"github.com/rossigee/provider-signoz/internal/controller/providerconfig"
+	"github.com/rossigee/provider-signoz/internal/controller/slo"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -38,9 +41,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	if err := alert.Setup(mgr, o); err != nil {
 		return err
 	}
+	if err := alerttemplate.Setup(mgr, o); err != nil {
+		return err
+	}
 	if err := channel.Setup(mgr, o); err != nil {
 		return err
 	}
+	if err := slo.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := plannedmaintenance.Setup(mgr, o); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -57,8 +69,17 @@ func SetupWithPCConfig(mgr ctrl.Manager, o controller.Options, pcReconciler prov
 	if err := alert.Setup(mgr, o); err != nil {
 		return err
 	}
+	if err := alerttemplate.Setup(mgr, o); err != nil {
+		return err
+	}
 	if err := channel.Setup(mgr, o); err != nil {
 		return err
 	}
+	if err := slo.Setup(mgr, o); err != nil {
+		return err
+	}
+	if err := plannedmaintenance.Setup(mgr, o); err != nil {
+		return err
+	}
 	return nil
 }
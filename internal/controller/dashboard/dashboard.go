@@ -38,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -56,16 +57,18 @@ const (
 // Setup adds a controller that reconciles Dashboard managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1beta1.Dashboard_GroupVersionKind.Kind)
+	recorder := clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
 
 	opts := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
 			kube:         resource.ClientApplicator{Client: mgr.GetClient(), Applicator: resource.NewAPIPatchingApplicator(mgr.GetClient())},
 			usage:        resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
-			newServiceFn: clients.NewClient,
+			newServiceFn: clients.CachedClient,
+			recorder:     recorder,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if o.Features != nil && o.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -82,7 +85,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1beta1.Dashboard{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, clients.NewBackoffReconciler(r, clients.DefaultBackoffPolicy), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -91,6 +94,7 @@ type connector struct {
 	kube         resource.ClientApplicator
 	usage        resource.ModernTracker
 	newServiceFn func(cfg clients.Config) *clients.Client
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -122,13 +126,15 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetCreds)
 	}
 
-	return &external{service: c.newServiceFn(*cfg)}, nil
+	return &external{service: c.newServiceFn(*cfg), kube: c.kube.Client, recorder: c.recorder}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service *clients.Client
+	service  *clients.Client
+	kube     client.Client
+	recorder event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -198,7 +204,24 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.SetConditions(xpv1.Available())
 
 	// Check if the dashboard is up to date (V2 version)
-	upToDate := isDashboardV2UpToDate(cr.Spec.ForProvider, dashboard)
+	desired, err := buildDashboardV2(cr.Spec.ForProvider, c.service.Capabilities())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	check := clients.DriftCheck{
+		SpecHash: clients.SpecHash(cr.Spec.ForProvider),
+		Payload:  desired,
+		Observed: dashboard,
+		Policy:   cr.Spec.ExternalChangePolicy,
+		Diff:     func() clients.Diff { return dashboardV2Diff(cr.Spec.ForProvider, dashboard) },
+	}
+	upToDate, drift, err := check.UpToDate(ctx, c.kube, c.recorder, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if drift != nil {
+		cr.Status.AtProvider.LastDrift = drift
+	}
 
 	logger := log.FromContext(ctx)
 	logger.V(1).Info("Dashboard observe", "name", cr.Name, "widgets_count", len(cr.Spec.ForProvider.Widgets), "panels_count", len(dashboard.Spec.Panels), "upToDate", upToDate)
@@ -215,20 +238,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDashboard)
 	}
 
-	description := ""
-	if cr.Spec.ForProvider.Description != nil {
-		description = *cr.Spec.ForProvider.Description
+	dashboardV2, err := buildDashboardV2(cr.Spec.ForProvider, c.service.Capabilities())
+	if err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
-	dashboardV2 := convertToV2(
-		cr.Spec.ForProvider.Title,
-		description,
-		cr.Spec.ForProvider.Tags,
-		cr.Spec.ForProvider.Widgets,
-		cr.Spec.ForProvider.Layout,
-		cr.Spec.ForProvider.Variables,
-	)
-
 	created, err := c.service.CreateDashboardV2(ctx, dashboardV2)
 	if err != nil {
 		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
@@ -261,27 +275,23 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New("dashboard ID not found")
 	}
 
-	description := ""
-	if cr.Spec.ForProvider.Description != nil {
-		description = *cr.Spec.ForProvider.Description
+	dashboardV2, err := buildDashboardV2(cr.Spec.ForProvider, c.service.Capabilities())
+	if err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
-	dashboardV2 := convertToV2(
-		cr.Spec.ForProvider.Title,
-		description,
-		cr.Spec.ForProvider.Tags,
-		cr.Spec.ForProvider.Widgets,
-		cr.Spec.ForProvider.Layout,
-		cr.Spec.ForProvider.Variables,
-	)
-
-	_, err := c.service.UpdateDashboardV2(ctx, dashboardID, dashboardV2)
+	_, err = c.service.UpdateDashboardV2(ctx, dashboardID, dashboardV2)
 	if err != nil {
 		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDashboard)
 	}
 	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
 
+	if err := clients.RecordApplied(ctx, c.kube, cr, clients.SpecHash(cr.Spec.ForProvider)); err != nil {
+		// The dashboard was updated; a missed count only delays loop detection.
+		log.FromContext(ctx).Info("Cannot record applied spec", "error", err)
+	}
+
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -341,212 +351,258 @@ func isDashboardUpToDate(spec v1beta1.DashboardParameters, dashboard *clients.Da
 	return true
 }
 
-func isDashboardV2UpToDate(spec v1beta1.DashboardParameters, dashboard *clients.DashboardV2Data) bool {
-	if spec.Title != dashboard.Spec.Display.Name {
-		return false
-	}
+// dashboardV2Diff returns the fields in which the observed SigNoz v2
+// dashboard differs from the desired spec.
+func dashboardV2Diff(spec v1beta1.DashboardParameters, dashboard *clients.DashboardV2Data) clients.Diff {
+	var d clients.Diff
 
 	expectedDesc := ""
 	if spec.Description != nil {
 		expectedDesc = *spec.Description
 	}
-	if dashboard.Spec.Display != nil && expectedDesc != dashboard.Spec.Display.Description {
-		return false
+	if dashboard.Spec.Display == nil {
+		d.Add("spec.display.name", spec.Title, nil)
+	} else {
+		if spec.Title != dashboard.Spec.Display.Name {
+			d.Add("spec.display.name", spec.Title, dashboard.Spec.Display.Name)
+		}
+		if expectedDesc != dashboard.Spec.Display.Description {
+			d.Add("spec.display.description", expectedDesc, dashboard.Spec.Display.Description)
+		}
 	}
 
 	// Compare tags
-	if len(spec.Tags) != len(dashboard.Tags) {
-		return false
-	}
-	for i, tag := range spec.Tags {
-		if i >= len(dashboard.Tags) || tag != dashboard.Tags[i].Key {
-			return false
-		}
+	observedTags := make([]string, len(dashboard.Tags))
+	for i, tag := range dashboard.Tags {
+		observedTags[i] = tag.Key
 	}
-
-	// Compare widget count
-	if len(spec.Widgets) != len(dashboard.Spec.Panels) {
-		return false
+	if !stringSlicesEqual(spec.Tags, observedTags) {
+		d.Add("tags", spec.Tags, observedTags)
 	}
 
 	// Compare each widget's content against the observed panel. Matching
 	// on ID alone is not enough: editing a query string inside an existing
 	// widget keeps the same ID and count, so that drift must be detected
 	// here or it is silently never applied (Update is never called).
+	desiredPanels := make(map[string]bool, len(spec.Widgets))
 	for _, w := range spec.Widgets {
+		desiredPanels[w.ID] = true
+		path := clients.JoinPath("spec.panels", w.ID)
 		panel, exists := dashboard.Spec.Panels[w.ID]
 		if !exists {
-			return false
+			d.Add(path, w.Title, nil)
+			continue
 		}
-		if !isPanelUpToDate(w, panel) {
-			return false
+		panelDiff(&d, path, w, panel)
+	}
+	for _, id := range sortedPanelIDs(dashboard.Spec.Panels) {
+		if !desiredPanels[id] {
+			d.Add(clients.JoinPath("spec.panels", id), nil, panelTitle(dashboard.Spec.Panels[id]))
 		}
 	}
 
-	return isVariablesUpToDate(spec.Variables, dashboard.Spec.Variables)
+	variablesDiff(&d, spec.Variables, dashboard.Spec.Variables)
+	return d
 }
 
-// isVariablesUpToDate compares the desired Variables map against the
-// observed SigNoz v6 variables array, keyed by each variable's spec.name.
-// Same rationale as isPanelUpToDate: without this, editing an existing
-// variable's query/value while nothing else on the dashboard changes would
-// be invisible to Observe() and Update() would never be called.
-func isVariablesUpToDate(variables map[string]v1beta1.Variable, observed []interface{}) bool {
-	if len(variables) != len(observed) {
-		return false
-	}
-
+// variablesDiff compares the desired Variables map against the observed
+// SigNoz v6 variables array, keyed by each variable's spec.name. Same
+// rationale as panelDiff: without this, editing an existing variable's
+// query/value while nothing else on the dashboard changes would be
+// invisible to Observe() and Update() would never be called.
+func variablesDiff(d *clients.Diff, variables map[string]v1beta1.Variable, observed []interface{}) {
 	observedByName := make(map[string]interface{}, len(observed))
-	for _, ov := range observed {
+	for i, ov := range observed {
 		ovMap, ok := ov.(map[string]interface{})
 		if !ok {
-			return false
+			d.Add(clients.IndexPath("spec.variables", i), nil, ov)
+			continue
 		}
 		name, ok := nestedString(ovMap, "spec", "name")
 		if !ok {
-			return false
+			d.Add(clients.IndexPath("spec.variables", i), nil, ov)
+			continue
 		}
 		observedByName[name] = ov
 	}
 
-	for name, v := range variables {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := clients.JoinPath("spec.variables", name)
 		ov, exists := observedByName[name]
 		if !exists {
-			return false
-		}
-		if !isVariableUpToDate(name, v, ov) {
-			return false
+			d.Add(path, variables[name].Type, nil)
+			continue
 		}
+		variableDiff(d, path, name, variables[name], ov)
 	}
 
-	return true
+	observedNames := make([]string, 0, len(observedByName))
+	for name := range observedByName {
+		observedNames = append(observedNames, name)
+	}
+	sort.Strings(observedNames)
+	for _, name := range observedNames {
+		if _, ok := variables[name]; !ok {
+			d.Add(clients.JoinPath("spec.variables", name), nil, name)
+		}
+	}
 }
 
-// isVariableUpToDate compares one desired Variable against its observed
-// SigNoz representation, using convertVariableToV2 as the single source of
-// truth for what Create/Update actually sends so the comparison can't drift
-// from it. Only compares fields convertVariableToV2 sets - name, and either
-// the textbox value or the plugin kind + query/custom value + allowMultiple/
+// variableDiff compares one desired Variable against its observed SigNoz
+// representation, using convertVariableToV2 as the single source of truth
+// for what Create/Update actually sends so the comparison can't drift from
+// it. Only compares fields convertVariableToV2 sets - name, and either the
+// textbox value or the plugin kind + query/custom value + allowMultiple/
 // allowAllValue - since the API fills in additional defaults (display,
 // sort, capturingRegexp, etc.) this provider never sends.
-func isVariableUpToDate(name string, v v1beta1.Variable, observed interface{}) bool {
+func variableDiff(d *clients.Diff, path, name string, v v1beta1.Variable, observed interface{}) {
 	expected := convertVariableToV2(name, v)
 
 	observedMap, ok := observed.(map[string]interface{})
 	if !ok {
-		return false
+		d.Add(path, expected, observed)
+		return
 	}
 	if expected["kind"] != observedMap["kind"] {
-		return false
+		d.Add(clients.JoinPath(path, "kind"), expected["kind"], observedMap["kind"])
+		return
 	}
 
-	expectedSpec, ok := expected["spec"].(map[string]interface{})
-	if !ok {
-		return false
-	}
+	expectedSpec, _ := expected["spec"].(map[string]interface{})
 	observedSpec, ok := observedMap["spec"].(map[string]interface{})
 	if !ok {
-		return false
-	}
-	if expectedSpec["name"] != observedSpec["name"] {
-		return false
+		d.Add(clients.JoinPath(path, "spec"), expectedSpec, observedMap["spec"])
+		return
 	}
+	specPath := clients.JoinPath(path, "spec")
 
+	keys := []string{"allowMultiple", "allowAllValue"}
 	if v.Type == "textbox" {
-		return expectedSpec["value"] == observedSpec["value"]
+		keys = []string{"value"}
 	}
-
-	if expectedSpec["allowMultiple"] != observedSpec["allowMultiple"] {
-		return false
+	for _, k := range keys {
+		if expectedSpec[k] != observedSpec[k] {
+			d.Add(clients.JoinPath(specPath, k), expectedSpec[k], observedSpec[k])
+		}
 	}
-	if expectedSpec["allowAllValue"] != observedSpec["allowAllValue"] {
-		return false
+	if v.Type == "textbox" {
+		return
 	}
 
-	expectedPlugin, ok := expectedSpec["plugin"].(map[string]interface{})
-	if !ok {
-		return false
-	}
+	pluginPath := clients.JoinPath(specPath, "plugin")
+	expectedPlugin, _ := expectedSpec["plugin"].(map[string]interface{})
 	observedPlugin, ok := observedSpec["plugin"].(map[string]interface{})
 	if !ok {
-		return false
+		d.Add(pluginPath, expectedPlugin, observedSpec["plugin"])
+		return
 	}
 	if expectedPlugin["kind"] != observedPlugin["kind"] {
-		return false
+		d.Add(clients.JoinPath(pluginPath, "kind"), expectedPlugin["kind"], observedPlugin["kind"])
+		return
 	}
 
-	expectedPluginSpec, ok := expectedPlugin["spec"].(map[string]interface{})
-	if !ok {
-		return false
-	}
+	expectedPluginSpec, _ := expectedPlugin["spec"].(map[string]interface{})
 	observedPluginSpec, ok := observedPlugin["spec"].(map[string]interface{})
 	if !ok {
-		return false
+		d.Add(clients.JoinPath(pluginPath, "spec"), expectedPluginSpec, observedPlugin["spec"])
+		return
 	}
 
+	key := "queryValue"
 	if v.Type == "custom" {
-		return expectedPluginSpec["customValue"] == observedPluginSpec["customValue"]
+		key = "customValue"
+	}
+	if expectedPluginSpec[key] != observedPluginSpec[key] {
+		d.Add(clients.JoinPath(clients.JoinPath(pluginPath, "spec"), key), expectedPluginSpec[key], observedPluginSpec[key])
 	}
-	return expectedPluginSpec["queryValue"] == observedPluginSpec["queryValue"]
 }
 
-// isPanelUpToDate compares a desired widget against the observed SigNoz v2
-// panel returned by the API. It only compares the fields convertToV2 /
+// panelDiff compares a desired widget against the observed SigNoz v2 panel
+// returned by the API. It only compares the fields convertToV2 /
 // convertQueryToV2 actually set - display name, Y-axis unit, and each
 // sub-query's type/query/legend/name/disabled - since the live API response
 // fills in many additional default fields (chart appearance, thresholds,
 // legend position, etc.) that this provider never sends and must not be
 // diffed against.
-func isPanelUpToDate(w v1beta1.Widget, panel interface{}) bool {
+func panelDiff(d *clients.Diff, path string, w v1beta1.Widget, panel interface{}) {
 	panelMap, ok := panel.(map[string]interface{})
 	if !ok {
-		return false
+		d.Add(path, w.Title, panel)
+		return
 	}
 	specMap, ok := panelMap["spec"].(map[string]interface{})
 	if !ok {
-		return false
+		d.Add(clients.JoinPath(path, "spec"), w.Title, panelMap["spec"])
+		return
 	}
 
-	displayMap, ok := specMap["display"].(map[string]interface{})
-	if !ok {
-		return false
-	}
-	if name, _ := displayMap["name"].(string); name != w.Title {
-		return false
+	if name, _ := nestedString(specMap, "display", "name"); name != w.Title {
+		d.Add(clients.JoinPath(path, "spec.display.name"), w.Title, name)
 	}
 
 	if w.YAxisUnit != nil {
 		unit, ok := nestedString(specMap, "plugin", "spec", "formatting", "unit")
 		if !ok || unit != *w.YAxisUnit {
-			return false
+			var observed interface{}
+			if ok {
+				observed = unit
+			}
+			d.Add(clients.JoinPath(path, "spec.plugin.spec.formatting.unit"), *w.YAxisUnit, observed)
 		}
 	}
 
-	observedQueries, ok := extractQuerySpecs(specMap)
-	if !ok {
-		return false
-	}
-
 	// convertQueryToV2 returns a single composite-query object; wrap it the
 	// same way convertToV2 does (panel.spec.queries = [compositeQuery]) so
 	// extractQuerySpecs can walk both observed and expected the same way.
 	expectedWrapped := map[string]interface{}{
 		"queries": []interface{}{convertQueryToV2(w.Query)},
 	}
-	expectedQueries, ok := extractQuerySpecs(expectedWrapped)
-	if !ok {
-		return false
+	expectedQueries, _ := extractQuerySpecs(expectedWrapped)
+	observedQueries, ok := extractQuerySpecs(specMap)
+	queriesPath := clients.JoinPath(path, "spec.queries")
+	if !ok || len(observedQueries) != len(expectedQueries) {
+		d.Add(queriesPath, expectedQueries, observedQueries)
+		return
+	}
+	for i := range expectedQueries {
+		if expectedQueries[i] != observedQueries[i] {
+			d.Add(clients.IndexPath(queriesPath, i), expectedQueries[i], observedQueries[i])
+		}
+	}
+}
+
+func panelTitle(panel interface{}) interface{} {
+	if m, ok := panel.(map[string]interface{}); ok {
+		if name, ok := nestedString(m, "spec", "display", "name"); ok {
+			return name
+		}
 	}
+	return panel
+}
 
-	if len(observedQueries) != len(expectedQueries) {
+func sortedPanelIDs(panels map[string]interface{}) []string {
+	ids := make([]string, 0, len(panels))
+	for id := range panels {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
 		return false
 	}
-	for i := range expectedQueries {
-		if expectedQueries[i] != observedQueries[i] {
+	for i := range a {
+		if a[i] != b[i] {
 			return false
 		}
 	}
-
 	return true
 }
 
@@ -740,11 +796,25 @@ func convertMetricsBuilder(builder v1beta1.MetricsBuilder) map[string]interface{
 	return result
 }
 
-func convertToV2(title, description string, tags []string, widgets []v1beta1.Widget, layout []v1beta1.Layout, variables map[string]v1beta1.Variable) *clients.DashboardV2Data {
+// buildDashboardV2 builds the API payload shared by Create and Update, in
+// the schema the server's capabilities call for.
+func buildDashboardV2(p v1beta1.DashboardParameters, caps clients.Capabilities) (*clients.DashboardV2Data, error) {
+	schemaVersion, err := caps.DashboardSchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	description := ""
+	if p.Description != nil {
+		description = *p.Description
+	}
+	return convertToV2(schemaVersion, p.Title, description, p.Tags, p.Widgets, p.Layout, p.Variables), nil
+}
+
+func convertToV2(schemaVersion, title, description string, tags []string, widgets []v1beta1.Widget, layout []v1beta1.Layout, variables map[string]v1beta1.Variable) *clients.DashboardV2Data {
 	v2name := strings.ToLower(strings.ReplaceAll(title, " ", "-"))
 	v2 := &clients.DashboardV2Data{
 		Name:          v2name,
-		SchemaVersion: "v6",
+		SchemaVersion: schemaVersion,
 		Tags:          make([]clients.DashboardTag, len(tags)),
 		Spec: clients.DashboardV2Spec{
 			Display: &clients.DashboardV2Display{
@@ -91,12 +91,12 @@ func TestIsDashboardUpToDate(t *testing.T) {
 	}
 }
 
-// TestIsDashboardV2UpToDate_DetectsQueryDrift reproduces the bug where
+// TestDashboardV2Diff_DetectsQueryDrift reproduces the bug where
 // editing a widget's query string (same widget ID, same widget count) was
-// invisible to Observe(): isDashboardV2UpToDate previously only compared
+// invisible to Observe(): the comparison previously only covered
 // widget IDs and count, never the query content, so Update() was never
 // called and the live SigNoz dashboard silently diverged from spec forever.
-func TestIsDashboardV2UpToDate_DetectsQueryDrift(t *testing.T) {
+func TestDashboardV2Diff_DetectsQueryDrift(t *testing.T) {
 	widget := v1beta1.Widget{
 		ID:        "dns-query-rate",
 		Title:     "DNS Query Rate",
@@ -126,8 +126,8 @@ func TestIsDashboardV2UpToDate_DetectsQueryDrift(t *testing.T) {
 			},
 		},
 	}
-	if !isDashboardV2UpToDate(spec, matching) {
-		t.Error("expected dashboard to be up to date when observed panel matches spec")
+	if d := dashboardV2Diff(spec, matching); !d.Empty() {
+		t.Errorf("expected dashboard to be up to date when observed panel matches spec, got %s", d)
 	}
 
 	// Same widget ID and widget count, but the live query string is the
@@ -144,8 +144,9 @@ func TestIsDashboardV2UpToDate_DetectsQueryDrift(t *testing.T) {
 			},
 		},
 	}
-	if isDashboardV2UpToDate(spec, drifted) {
-		t.Error("expected dashboard to be detected as out of date when the live query string differs from spec")
+	d := dashboardV2Diff(spec, drifted)
+	if want := "spec.panels.dns-query-rate.spec.queries[0]"; len(d) != 1 || d[0].Path != want {
+		t.Errorf("dashboardV2Diff() = %s, want a single difference at %s", d, want)
 	}
 }
 
@@ -349,12 +350,12 @@ func TestConvertVariablesToV2_Empty(t *testing.T) {
 	}
 }
 
-// TestIsDashboardV2UpToDate_DetectsVariableDrift guards against the same
-// bug class as TestIsDashboardV2UpToDate_DetectsQueryDrift, but for
-// variables: since isDashboardV2UpToDate now compares variables too,
+// TestDashboardV2Diff_DetectsVariableDrift guards against the same
+// bug class as TestDashboardV2Diff_DetectsQueryDrift, but for
+// variables: since dashboardV2Diff now compares variables too,
 // editing a variable's query while nothing else on the dashboard changes
 // must be detected, or Update() would silently never be called for it.
-func TestIsDashboardV2UpToDate_DetectsVariableDrift(t *testing.T) {
+func TestDashboardV2Diff_DetectsVariableDrift(t *testing.T) {
 	spec := v1beta1.DashboardParameters{
 		Title: "CoreDNS Monitoring",
 		Variables: map[string]v1beta1.Variable{
@@ -387,8 +388,8 @@ func TestIsDashboardV2UpToDate_DetectsVariableDrift(t *testing.T) {
 			},
 		},
 	}
-	if !isDashboardV2UpToDate(spec, matching) {
-		t.Error("expected dashboard to be up to date when observed variable matches spec")
+	if d := dashboardV2Diff(spec, matching); !d.Empty() {
+		t.Errorf("expected dashboard to be up to date when observed variable matches spec, got %s", d)
 	}
 
 	// Same variable name, dashboard otherwise identical, but the live
@@ -415,8 +416,9 @@ func TestIsDashboardV2UpToDate_DetectsVariableDrift(t *testing.T) {
 			},
 		},
 	}
-	if isDashboardV2UpToDate(spec, drifted) {
-		t.Error("expected dashboard to be detected as out of date when the live variable query differs from spec")
+	d := dashboardV2Diff(spec, drifted)
+	if want := "spec.variables.host_name.spec.plugin.spec.queryValue"; len(d) != 1 || d[0].Path != want {
+		t.Errorf("dashboardV2Diff() = %s, want a single difference at %s", d, want)
 	}
 }
 
@@ -0,0 +1,410 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package plannedmaintenance reconciles PlannedMaintenances as SigNoz
+// downtime schedules.
+package plannedmaintenance
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	apisv1beta1 "github.com/rossigee/provider-signoz/apis/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	errNotMaintenance     = "managed resource is not a PlannedMaintenance custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage"
+	errGetPC              = "cannot get ProviderConfig"
+	errGetCreds           = "cannot get credentials"
+	errCreateMaintenance  = "cannot create planned maintenance"
+	errUpdateMaintenance  = "cannot update planned maintenance"
+	errDeleteMaintenance  = "cannot delete planned maintenance"
+	errGetMaintenance     = "cannot get planned maintenance"
+	errResolveAlerts      = "cannot resolve alerts"
+	errAlertsPending      = "referenced alerts are not ready"
+	errInvalidSelector    = "invalid alert selector"
+	errNoAlertsSelected   = "alert selector matched no alerts"
+	errMaintenanceMissing = "planned maintenance ID not found"
+)
+
+// Setup adds a controller that reconciles PlannedMaintenance managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1beta1.PlannedMaintenance_GroupVersionKind.Kind)
+	recorder := clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(name)))
+
+	opts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			kube:         resource.ClientApplicator{Client: mgr.GetClient(), Applicator: resource.NewAPIPatchingApplicator(mgr.GetClient())},
+			usage:        resource.ModernTrackerFn(func(ctx context.Context, mg resource.ModernManaged) error { return nil }),
+			newServiceFn: clients.CachedClient,
+			recorder:     recorder,
+		}),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithRecorder(recorder),
+	}
+
+	if o.Features != nil && o.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		opts = append(opts, managed.WithManagementPolicies())
+	}
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1beta1.PlannedMaintenance_GroupVersionKind),
+		opts...,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.PlannedMaintenance{}).
+		Complete(ratelimiter.NewReconciler(name, clients.NewBackoffReconciler(r, clients.DefaultBackoffPolicy), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         resource.ClientApplicator
+	usage        resource.ModernTracker
+	newServiceFn func(cfg clients.Config) *clients.Client
+	recorder     event.Recorder
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.PlannedMaintenance)
+	if !ok {
+		return nil, errors.New(errNotMaintenance)
+	}
+
+	if err := c.usage.Track(ctx, mg.(resource.ModernManaged)); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	if cr.GetProviderConfigReference() == nil {
+		return nil, errors.New("no providerConfigRef provided")
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: "", Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	cfg, err := clients.GetConfig(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	return &external{
+		service:  c.newServiceFn(*cfg),
+		kube:     c.kube.Client,
+		recorder: c.recorder,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service  *clients.Client
+	kube     client.Client
+	recorder event.Recorder
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.PlannedMaintenance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMaintenance)
+	}
+
+	id, ok := maintenanceID(cr)
+	if !ok {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, err := c.service.GetPlannedMaintenance(ctx, id)
+	if err != nil {
+		if clients.IsNotFound(err) {
+			clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetMaintenance)
+	}
+	if observed == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
+
+	cr.Status.AtProvider.ID = observed.ID
+	cr.Status.AtProvider.Status = observed.Status
+	cr.Status.AtProvider.Kind = observed.Kind
+	if t, err := time.Parse(time.RFC3339, observed.CreatedAt); err == nil {
+		cr.Status.AtProvider.CreatedAt = &metav1.Time{Time: t}
+	}
+	if t, err := time.Parse(time.RFC3339, observed.UpdatedAt); err == nil {
+		cr.Status.AtProvider.UpdatedAt = &metav1.Time{Time: t}
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	// A PlannedMaintenance being deleted only needs its schedule deleted.
+	// Its Alerts may be gone already, so they aren't resolved: an error now
+	// would stop the managed reconciler before it deletes the schedule.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	alertIDs, pending, err := c.resolveAlerts(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveAlerts)
+	}
+
+	// While referenced Alerts are pending the schedule is left as-is: an
+	// Update now would stop silencing them.
+	upToDate := len(pending) > 0
+	if !upToDate {
+		desired, err := buildMaintenanceData(cr.Spec.ForProvider, alertIDs)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		check := clients.DriftCheck{
+			SpecHash: clients.SpecHash(cr.Spec.ForProvider),
+			Payload:  desired,
+			Observed: observed,
+			Policy:   cr.Spec.ExternalChangePolicy,
+			Diff:     func() clients.Diff { return clients.PlannedMaintenanceDiff(desired, observed) },
+		}
+		var drift *apisv1beta1.DriftSummary
+		if upToDate, drift, err = check.UpToDate(ctx, c.kube, c.recorder, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if drift != nil {
+			cr.Status.AtProvider.LastDrift = drift
+		}
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.PlannedMaintenance)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMaintenance)
+	}
+
+	data, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	created, err := c.service.CreatePlannedMaintenance(ctx, data)
+	if err != nil {
+		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateMaintenance)
+	}
+	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
+
+	meta.SetExternalName(cr, created.ID)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.PlannedMaintenance)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMaintenance)
+	}
+
+	id, ok := maintenanceID(cr)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errMaintenanceMissing)
+	}
+
+	data, err := c.desired(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.service.UpdatePlannedMaintenance(ctx, id, data); err != nil {
+		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateMaintenance)
+	}
+	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
+
+	if err := clients.RecordApplied(ctx, c.kube, cr, clients.SpecHash(cr.Spec.ForProvider)); err != nil {
+		// The schedule was updated; a missed count only delays loop detection.
+		log.FromContext(ctx).Info("Cannot record applied spec", "error", err)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1beta1.PlannedMaintenance)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotMaintenance)
+	}
+
+	id, ok := maintenanceID(cr)
+	if !ok {
+		return managed.ExternalDelete{}, nil // Nothing to delete
+	}
+
+	err := c.service.DeletePlannedMaintenance(ctx, id)
+	if err != nil && !clients.IsNotFound(err) {
+		clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, err, false)
+		return managed.ExternalDelete{}, errors.Wrap(err, errDeleteMaintenance)
+	}
+	clients.RecordUpstreamCondition(ctx, &cr.Status.ConditionedStatus, nil, true)
+
+	return managed.ExternalDelete{}, nil
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	// Nothing to disconnect for SigNoz API client
+	return nil
+}
+
+// desired validates the PlannedMaintenance, resolves the Alerts it silences
+// and builds the downtime schedule to send to SigNoz. It fails while any
+// referenced Alert is pending.
+func (c *external) desired(ctx context.Context, cr *v1beta1.PlannedMaintenance) (*clients.PlannedMaintenanceData, error) {
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return nil, err
+	}
+	alertIDs, pending, err := c.resolveAlerts(ctx, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveAlerts)
+	}
+	if len(pending) > 0 {
+		return nil, errors.Errorf("%s: %s", errAlertsPending, strings.Join(pending, ", "))
+	}
+	return buildMaintenanceData(cr.Spec.ForProvider, alertIDs)
+}
+
+// resolveAlerts returns the SigNoz rule IDs of the alerts the
+// PlannedMaintenance silences, and the names of referenced or selected
+// Alerts that don't exist in SigNoz yet, including referenced Alerts that
+// don't exist at all. The result is recorded in
+// status.atProvider.resolvedAlertIds.
+func (c *external) resolveAlerts(ctx context.Context, cr *v1beta1.PlannedMaintenance) ([]string, []string, error) {
+	p := cr.Spec.ForProvider
+	ids := append([]string{}, p.AlertIDs...)
+	var pending []string
+	add := func(a *v1beta1.Alert) {
+		if !alertReady(a) {
+			pending = append(pending, a.GetName())
+			return
+		}
+		ids = append(ids, meta.GetExternalName(a))
+	}
+
+	for _, ref := range p.AlertRefs {
+		a := &v1beta1.Alert{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: cr.GetNamespace(), Name: ref.Name}, a); err != nil {
+			if kerrors.IsNotFound(err) {
+				pending = append(pending, ref.Name)
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "cannot get alert %s", ref.Name)
+		}
+		add(a)
+	}
+
+	if p.AlertSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(p.AlertSelector)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errInvalidSelector)
+		}
+		alerts := &v1beta1.AlertList{}
+		if err := c.kube.List(ctx, alerts, client.InNamespace(cr.GetNamespace()), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return nil, nil, errors.Wrap(err, "cannot list alerts")
+		}
+		if len(alerts.Items) == 0 {
+			return nil, nil, errors.Errorf("%s in namespace %s", errNoAlertsSelected, cr.GetNamespace())
+		}
+		for i := range alerts.Items {
+			add(&alerts.Items[i])
+		}
+	}
+
+	ids = uniqueSorted(ids)
+	cr.Status.AtProvider.ResolvedAlertIDs = ids
+	return ids, pending, nil
+}
+
+// alertReady reports whether an Alert exists in SigNoz: it must be Ready and
+// carry the ID of the upstream rule.
+func alertReady(a *v1beta1.Alert) bool {
+	return a.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue &&
+		meta.GetExternalName(a) != ""
+}
+
+// maintenanceID returns the ID of the schedule in SigNoz: the external name
+// once Create has recorded it, or one set by hand to adopt a schedule. Until
+// then the external name is the resource's name, which Crossplane defaults
+// it to, however much it looks like an ID.
+func maintenanceID(cr *v1beta1.PlannedMaintenance) (string, bool) {
+	id := meta.GetExternalName(cr)
+	if id == "" || (id == cr.GetName() && meta.GetExternalCreateSucceeded(cr).IsZero()) {
+		return "", false
+	}
+	return id, true
+}
+
+func uniqueSorted(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := make([]string, 0, len(s))
+	for _, v := range s {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
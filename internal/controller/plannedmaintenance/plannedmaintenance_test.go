@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plannedmaintenance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add alert types to scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+// alert returns an Alert that has been created in SigNoz as rule id, or a
+// pending one if id is empty.
+func alert(name, id string, labels map[string]string) *v1beta1.Alert {
+	a := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "monitoring", Labels: labels}}
+	if id != "" {
+		meta.SetExternalName(a, id)
+		a.Status.SetConditions(xpv1.Available())
+	}
+	return a
+}
+
+func TestResolveAlerts(t *testing.T) {
+	e := &external{kube: newFakeKube(t,
+		alert("latency", "rule-2", map[string]string{"team": "api"}),
+		alert("errors", "rule-1", map[string]string{"team": "api"}),
+		alert("new", "", map[string]string{"team": "api"}),
+		alert("disk", "rule-3", nil),
+	)}
+
+	cr := &v1beta1.PlannedMaintenance{ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "monitoring"}}
+	cr.Spec.ForProvider.AlertIDs = []string{"rule-9"}
+	cr.Spec.ForProvider.AlertRefs = []xpv1.Reference{{Name: "disk"}, {Name: "latency"}}
+	cr.Spec.ForProvider.AlertSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "api"}}
+
+	ids, pending, err := e.resolveAlerts(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("resolveAlerts() error = %v", err)
+	}
+	if strings.Join(ids, ",") != "rule-1,rule-2,rule-3,rule-9" {
+		t.Errorf("ids = %v, want the direct, referenced and selected IDs once each", ids)
+	}
+	if len(pending) != 1 || pending[0] != "new" {
+		t.Errorf("pending = %v, want [new]", pending)
+	}
+	if len(cr.Status.AtProvider.ResolvedAlertIDs) != 4 {
+		t.Errorf("resolvedAlertIds = %v", cr.Status.AtProvider.ResolvedAlertIDs)
+	}
+
+	// Create and Update wait for pending Alerts.
+	cr.Spec.ForProvider.Schedule = oneOff().Schedule
+	if _, err := e.desired(context.Background(), cr); err == nil || !strings.Contains(err.Error(), "new") {
+		t.Errorf("desired() error = %v, want the pending Alert named", err)
+	}
+
+	// Referenced Alerts that don't exist are pending too.
+	cr.Spec.ForProvider.AlertRefs = append(cr.Spec.ForProvider.AlertRefs, xpv1.Reference{Name: "gone"})
+	if _, pending, err := e.resolveAlerts(context.Background(), cr); err != nil || strings.Join(pending, ",") != "gone,new" {
+		t.Errorf("resolveAlerts() = %v, %v, want [gone new] pending", pending, err)
+	}
+
+	cr.Spec.ForProvider.AlertSelector.MatchLabels = map[string]string{"team": "web"}
+	if _, _, err := e.resolveAlerts(context.Background(), cr); err == nil || !strings.Contains(err.Error(), errNoAlertsSelected) {
+		t.Errorf("resolveAlerts() error = %v, want %q", err, errNoAlertsSelected)
+	}
+}
+
+// A PlannedMaintenance whose Alerts were deleted first must still be
+// observed, so the managed reconciler goes on to delete its schedule.
+func TestObserve_DeletedWithoutAlerts(t *testing.T) {
+	id := "0d4d1e43-2c1d-4f2e-9b5a-3e2f6d8c9a10"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"id":%q,"name":"db-upgrade"}}`, id)
+	}))
+	defer ts.Close()
+	e := &external{
+		kube:    newFakeKube(t),
+		service: clients.NewClient(clients.Config{BaseURL: ts.URL, APIKey: "test-api-key-1234567890"}),
+	}
+
+	cr := &v1beta1.PlannedMaintenance{ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "monitoring"}}
+	cr.Spec.ForProvider = oneOff()
+	cr.Spec.ForProvider.AlertSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "api"}}
+	meta.SetExternalName(cr, id)
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+
+	obs, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	if !obs.ResourceExists {
+		t.Error("Observe() ResourceExists = false, want the schedule to be deleted")
+	}
+}
+
+func TestMaintenanceID(t *testing.T) {
+	named := func(name, externalName string, created bool) *v1beta1.PlannedMaintenance {
+		cr := &v1beta1.PlannedMaintenance{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		meta.SetExternalName(cr, externalName)
+		if created {
+			meta.SetExternalCreateSucceeded(cr, time.Now())
+		}
+		return cr
+	}
+	cases := map[string]struct {
+		cr     *v1beta1.PlannedMaintenance
+		wantID string
+		wantOK bool
+	}{
+		"NoExternalName":  {cr: named("db-upgrade", "", false)},
+		"DefaultedName":   {cr: named("db-upgrade", "db-upgrade", false)},
+		"NameLooksLikeID": {cr: named("2024", "2024", false)},
+		"Created":         {cr: named("42", "42", true), wantID: "42", wantOK: true},
+		"Adopted":         {cr: named("db-upgrade", "0190f3a2-8b7c-7def-8123-456789abcdef", false), wantID: "0190f3a2-8b7c-7def-8123-456789abcdef", wantOK: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, ok := maintenanceID(tc.cr)
+			if id != tc.wantID || ok != tc.wantOK {
+				t.Errorf("maintenanceID() = %q, %v, want %q, %v", id, ok, tc.wantID, tc.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plannedmaintenance
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+)
+
+const (
+	errNoAlerts          = "one of allAlerts, alertIds, alertRefs or alertSelector must be set"
+	errAllAlertsExcluded = "allAlerts cannot be combined with alertIds, alertRefs or alertSelector"
+	errTimezone          = "invalid schedule timezone"
+	errStartTime         = "invalid schedule startTime"
+	errEndTime           = "invalid schedule endTime"
+	errEndRequired       = "a one-off schedule must set endTime"
+	errEndBeforeStart    = "schedule endTime must be after startTime"
+	errDuration          = "invalid recurrence duration"
+)
+
+// localTimeLayouts are the accepted formats of schedule start and end times,
+// which are wall-clock times in the schedule's timezone.
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04"}
+
+// validate checks what the CRD schema can't: that the silenced alerts are
+// chosen one way, and that the schedule's times are consistent.
+func validate(p v1beta1.PlannedMaintenanceParameters) error {
+	byList := len(p.AlertIDs) > 0 || len(p.AlertRefs) > 0 || p.AlertSelector != nil
+	switch {
+	case p.AllAlerts && byList:
+		return errors.New(errAllAlertsExcluded)
+	case !p.AllAlerts && !byList:
+		return errors.New(errNoAlerts)
+	}
+	_, err := buildSchedule(p.Schedule)
+	return err
+}
+
+// buildMaintenanceData builds the SigNoz downtime schedule for a
+// PlannedMaintenance. An empty AlertIDs silences every alert rule.
+func buildMaintenanceData(p v1beta1.PlannedMaintenanceParameters, alertIDs []string) (*clients.PlannedMaintenanceData, error) {
+	schedule, err := buildSchedule(p.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	if !p.AllAlerts {
+		ids = append(ids, alertIDs...)
+		sort.Strings(ids)
+	}
+	return &clients.PlannedMaintenanceData{
+		Name:        p.Name,
+		Description: p.Description,
+		Schedule:    schedule,
+		AlertIDs:    ids,
+	}, nil
+}
+
+// buildSchedule converts the schedule's local times into RFC 3339 times in
+// its timezone. A recurring schedule starts and ends its recurrence at the
+// schedule's own start and end.
+func buildSchedule(s v1beta1.MaintenanceSchedule) (*clients.PlannedMaintenanceSchedule, error) {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, errors.Wrap(err, errTimezone)
+	}
+	start, err := parseLocalTime(s.StartTime, loc)
+	if err != nil {
+		return nil, errors.Wrap(err, errStartTime)
+	}
+	var end time.Time
+	if s.EndTime != "" {
+		if end, err = parseLocalTime(s.EndTime, loc); err != nil {
+			return nil, errors.Wrap(err, errEndTime)
+		}
+		if !end.After(start) {
+			return nil, errors.New(errEndBeforeStart)
+		}
+	}
+
+	out := &clients.PlannedMaintenanceSchedule{
+		Timezone:  s.Timezone,
+		StartTime: start.Format(time.RFC3339),
+		EndTime:   formatTime(end),
+	}
+	r := s.Recurrence
+	if r == nil {
+		if end.IsZero() {
+			return nil, errors.New(errEndRequired)
+		}
+		return out, nil
+	}
+
+	d, err := time.ParseDuration(r.Duration)
+	if err != nil {
+		return nil, errors.Wrap(err, errDuration)
+	}
+	if d <= 0 {
+		return nil, errors.Errorf("%s: %s is not positive", errDuration, r.Duration)
+	}
+	repeatOn := append([]string{}, r.RepeatOn...)
+	if r.RepeatType == "weekly" && len(repeatOn) == 0 {
+		repeatOn = []string{strings.ToLower(start.Weekday().String())}
+	}
+	out.Recurrence = &clients.PlannedMaintenanceRecurrence{
+		StartTime:  out.StartTime,
+		EndTime:    out.EndTime,
+		Duration:   d.String(),
+		RepeatType: r.RepeatType,
+		RepeatOn:   repeatOn,
+	}
+	return out, nil
+}
+
+func parseLocalTime(s string, loc *time.Location) (time.Time, error) {
+	var err error
+	for _, layout := range localTimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plannedmaintenance
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+)
+
+func oneOff() v1beta1.PlannedMaintenanceParameters {
+	return v1beta1.PlannedMaintenanceParameters{
+		Name:        "db-upgrade",
+		Description: "Postgres 16 upgrade",
+		Schedule: v1beta1.MaintenanceSchedule{
+			Timezone:  "Europe/London",
+			StartTime: "2025-07-01T22:00",
+			EndTime:   "2025-07-02T01:30:00",
+		},
+		AlertIDs: []string{"b", "a"},
+	}
+}
+
+func TestBuildMaintenanceData(t *testing.T) {
+	got, err := buildMaintenanceData(oneOff(), []string{"b", "a"})
+	if err != nil {
+		t.Fatalf("buildMaintenanceData() error = %v", err)
+	}
+	if got.Name != "db-upgrade" || got.Description != "Postgres 16 upgrade" {
+		t.Errorf("name, description = %q, %q", got.Name, got.Description)
+	}
+	if len(got.AlertIDs) != 2 || got.AlertIDs[0] != "a" {
+		t.Errorf("alertIds = %v, want them sorted", got.AlertIDs)
+	}
+	// Local times are sent with the zone's offset on that date.
+	s := got.Schedule
+	if s.Timezone != "Europe/London" || s.StartTime != "2025-07-01T22:00:00+01:00" || s.EndTime != "2025-07-02T01:30:00+01:00" {
+		t.Errorf("schedule = %+v", s)
+	}
+	if s.Recurrence != nil {
+		t.Errorf("one-off schedule has a recurrence: %+v", s.Recurrence)
+	}
+
+	all := oneOff()
+	all.AlertIDs = nil
+	all.AllAlerts = true
+	got, err = buildMaintenanceData(all, []string{"ignored"})
+	if err != nil {
+		t.Fatalf("buildMaintenanceData() error = %v", err)
+	}
+	if got.AlertIDs == nil || len(got.AlertIDs) != 0 {
+		t.Errorf("allAlerts alertIds = %#v, want an empty list", got.AlertIDs)
+	}
+}
+
+func TestBuildMaintenanceData_Recurring(t *testing.T) {
+	p := oneOff()
+	p.Schedule = v1beta1.MaintenanceSchedule{
+		Timezone:   "America/New_York",
+		StartTime:  "2025-01-07T02:00",
+		Recurrence: &v1beta1.MaintenanceRecurrence{Duration: "90m", RepeatType: "weekly"},
+	}
+	got, err := buildMaintenanceData(p, nil)
+	if err != nil {
+		t.Fatalf("buildMaintenanceData() error = %v", err)
+	}
+	s := got.Schedule
+	if s.StartTime != "2025-01-07T02:00:00-05:00" || s.EndTime != "" {
+		t.Errorf("schedule start, end = %q, %q", s.StartTime, s.EndTime)
+	}
+	r := s.Recurrence
+	if r == nil {
+		t.Fatal("recurring schedule has no recurrence")
+	}
+	if r.StartTime != s.StartTime || r.Duration != "1h30m0s" || r.RepeatType != "weekly" {
+		t.Errorf("recurrence = %+v", r)
+	}
+	if len(r.RepeatOn) != 1 || r.RepeatOn[0] != "tuesday" {
+		t.Errorf("repeatOn = %v, want the start's weekday", r.RepeatOn)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		mutate func(p *v1beta1.PlannedMaintenanceParameters)
+		want   string
+	}{
+		"Valid": {mutate: func(p *v1beta1.PlannedMaintenanceParameters) {}},
+		"NoAlerts": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) { p.AlertIDs = nil },
+			want:   errNoAlerts,
+		},
+		"AllAlertsAndIDs": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) { p.AllAlerts = true },
+			want:   errAllAlertsExcluded,
+		},
+		"UnknownTimezone": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) { p.Schedule.Timezone = "Mars/Olympus_Mons" },
+			want:   errTimezone,
+		},
+		"OneOffWithoutEnd": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) { p.Schedule.EndTime = "" },
+			want:   errEndRequired,
+		},
+		"EndBeforeStart": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) { p.Schedule.EndTime = "2025-07-01T21:00" },
+			want:   errEndBeforeStart,
+		},
+		"BadDuration": {
+			mutate: func(p *v1beta1.PlannedMaintenanceParameters) {
+				p.Schedule.Recurrence = &v1beta1.MaintenanceRecurrence{Duration: "1 day", RepeatType: "daily"}
+			},
+			want: errDuration,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := oneOff()
+			tc.mutate(&p)
+			err := validate(p)
+			switch {
+			case tc.want == "" && err != nil:
+				t.Errorf("validate() error = %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Errorf("validate() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestMaintenanceDiff(t *testing.T) {
+	p := oneOff()
+	p.Schedule.Recurrence = &v1beta1.MaintenanceRecurrence{Duration: "2h", RepeatType: "daily"}
+	desired, err := buildMaintenanceData(p, p.AlertIDs)
+	if err != nil {
+		t.Fatalf("buildMaintenanceData() error = %v", err)
+	}
+
+	// SigNoz renders the same instants in UTC, durations in full, and
+	// reports the schedule's status; none of that is drift.
+	observed := &clients.PlannedMaintenanceData{
+		ID:          "7",
+		Name:        "db-upgrade",
+		Description: "Postgres 16 upgrade",
+		AlertIDs:    []string{"a", "b"},
+		Status:      "upcoming",
+		Schedule: &clients.PlannedMaintenanceSchedule{
+			Timezone:  "Europe/London",
+			StartTime: "2025-07-01T21:00:00Z",
+			EndTime:   "2025-07-02T00:30:00.000Z",
+			Recurrence: &clients.PlannedMaintenanceRecurrence{
+				StartTime:  "2025-07-01T21:00:00Z",
+				EndTime:    "2025-07-02T00:30:00Z",
+				Duration:   "2h0m0s",
+				RepeatType: "daily",
+			},
+		},
+	}
+	if d := clients.PlannedMaintenanceDiff(desired, observed); !d.Empty() {
+		t.Errorf("PlannedMaintenanceDiff() = %s, want no drift", d)
+	}
+
+	observed.AlertIDs = []string{"a"}
+	observed.Schedule.StartTime = "2025-07-01T22:00:00Z"
+	observed.Schedule.Recurrence.Duration = "1h"
+	observed.Schedule.Recurrence.RepeatOn = []string{"monday"}
+	d := clients.PlannedMaintenanceDiff(desired, observed)
+	want := []string{"alertIds", "schedule.startTime", "schedule.recurrence.duration", "schedule.recurrence.repeatOn"}
+	if len(d) != len(want) {
+		t.Fatalf("PlannedMaintenanceDiff() = %s, want %v", d, want)
+	}
+	for i, path := range want {
+		if d[i].Path != path {
+			t.Errorf("diff[%d] = %s, want %s", i, d[i].Path, path)
+		}
+	}
+
+	// A one-off schedule drifts if someone makes it recur, and zero times
+	// are treated as unset.
+	desired, _ = buildMaintenanceData(oneOff(), []string{"a", "b"})
+	observed.AlertIDs = []string{"a", "b"}
+	observed.Schedule.StartTime = desired.Schedule.StartTime
+	if d := clients.PlannedMaintenanceDiff(desired, observed); len(d) != 1 || d[0].Path != "schedule.recurrence" {
+		t.Errorf("PlannedMaintenanceDiff() = %s, want only schedule.recurrence", d)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// LabelImport opts a PrometheusRule in to being imported when set to
+	// "true".
+	LabelImport = "signoz.m.crossplane.io/import"
+
+	// LabelPrometheusRule is set on imported Alerts to the name of their
+	// PrometheusRule.
+	LabelPrometheusRule = "signoz.m.crossplane.io/prometheusrule"
+
+	// AnnotationChannelSelector overrides the default channel selector of a
+	// PrometheusRule's Alerts. It takes a label selector, e.g.
+	// "team=platform,tier in (1,2)".
+	AnnotationChannelSelector = "signoz.m.crossplane.io/channel-selector"
+
+	// AnnotationRuleHash holds the hash of the metadata and spec an imported
+	// Alert was last written from, so it is only updated when its rule
+	// changes.
+	AnnotationRuleHash = "signoz.m.crossplane.io/prometheusrule-hash"
+
+	defaultFrequency = time.Minute
+	defaultSeverity  = "warning"
+	severityLabel    = "severity"
+)
+
+const (
+	errNoExpr          = "rule has no expr"
+	errInvalidFor      = "invalid for duration"
+	errInvalidInterval = "invalid group interval"
+	errInvalidName     = "invalid Alert name"
+	errInvalidSelector = "invalid channel selector"
+)
+
+// severities maps common Prometheus severity label values onto SigNoz
+// severities.
+var severities = map[string]string{
+	"critical": "critical",
+	"page":     "critical",
+	"error":    "error",
+	"high":     "error",
+	"major":    "error",
+	"warning":  "warning",
+	"warn":     "warning",
+	"medium":   "warning",
+	"minor":    "warning",
+	"info":     "info",
+	"low":      "info",
+	"none":     "info",
+}
+
+// valueRef matches a reference to $value in an annotation template.
+var valueRef = regexp.MustCompile(`\$value\b`)
+
+var dashes = regexp.MustCompile(`-+`)
+
+// ruleSpec mirrors the parts of the monitoring.coreos.com/v1 PrometheusRule
+// spec that are imported.
+type ruleSpec struct {
+	Groups []ruleGroup `json:"groups"`
+}
+
+type ruleGroup struct {
+	Name     string            `json:"name"`
+	Interval string            `json:"interval,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Rules    []rule            `json:"rules"`
+}
+
+type rule struct {
+	Record        string             `json:"record,omitempty"`
+	Alert         string             `json:"alert,omitempty"`
+	Expr          intstr.IntOrString `json:"expr"`
+	For           string             `json:"for,omitempty"`
+	KeepFiringFor string             `json:"keep_firing_for,omitempty"`
+	Labels        map[string]string  `json:"labels,omitempty"`
+	Annotations   map[string]string  `json:"annotations,omitempty"`
+}
+
+// A source is the PrometheusRule rules are imported from.
+type source struct {
+	name      string
+	namespace string
+	selector  *v1beta1.ChannelSelector
+}
+
+// A conversion is the outcome of importing one alerting rule. Name is set
+// whenever the rule's Alert name is valid, even if the rule itself could
+// not be converted, so its existing Alert isn't pruned because of a typo.
+type conversion struct {
+	Group    string
+	Rule     string
+	Name     string
+	Alert    *v1beta1.Alert
+	Err      error
+	Warnings []string
+}
+
+// ParseChannelSelector parses a label selector, such as
+// "team=platform,tier in (1,2)", into a ChannelSelector. It returns nil for
+// an empty selector.
+func ParseChannelSelector(s string) (*v1beta1.ChannelSelector, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	sel, err := metav1.ParseToLabelSelector(s)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidSelector)
+	}
+	return &v1beta1.ChannelSelector{MatchLabels: sel.MatchLabels, MatchExpressions: sel.MatchExpressions}, nil
+}
+
+// convert imports the alerting rules of a PrometheusRule spec. Recording
+// rules are skipped.
+func convert(src source, spec ruleSpec) []conversion {
+	var out []conversion
+	used := map[string]int{}
+	for _, g := range spec.Groups {
+		for _, r := range g.Rules {
+			if r.Alert == "" {
+				continue
+			}
+			c := conversion{Group: g.Name, Rule: r.Alert}
+			name := src.name + "-" + slug(r.Alert)
+			if used[name]++; used[name] > 1 {
+				name = fmt.Sprintf("%s-%d", name, used[name])
+			}
+			if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+				c.Err = errors.Errorf("%s %q: %s", errInvalidName, name, strings.Join(errs, ", "))
+				out = append(out, c)
+				continue
+			}
+			c.Name = name
+			c.Alert, c.Warnings, c.Err = convertRule(src, g, r, name)
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// convertRule converts one alerting rule into an Alert. It returns
+// warnings for the parts of the rule SigNoz can't express.
+func convertRule(src source, g ruleGroup, r rule, name string) (*v1beta1.Alert, []string, error) {
+	// A missing expr decodes as the integer 0.
+	expr := strings.TrimSpace(r.Expr.String())
+	if expr == "" || r.Expr == (intstr.IntOrString{}) {
+		return nil, nil, errors.New(errNoExpr)
+	}
+
+	frequency := defaultFrequency
+	if g.Interval != "" {
+		d, err := model.ParseDuration(g.Interval)
+		if err != nil || d <= 0 {
+			return nil, nil, errors.Errorf("%s %q", errInvalidInterval, g.Interval)
+		}
+		frequency = time.Duration(d)
+	}
+
+	// A Prometheus rule without a for duration fires as soon as its
+	// expression returns a series; one with a for duration fires once the
+	// series has been returned for all of it.
+	evalWindow, matchType := frequency, 1
+	if r.For != "" {
+		d, err := model.ParseDuration(r.For)
+		if err != nil {
+			return nil, nil, errors.Errorf("%s %q", errInvalidFor, r.For)
+		}
+		if d > 0 {
+			evalWindow, matchType = time.Duration(d), 2
+		}
+	}
+
+	var warnings []string
+	if r.KeepFiringFor != "" {
+		warnings = append(warnings, fmt.Sprintf("keep_firing_for %s is not supported and was ignored", r.KeepFiringFor))
+	}
+
+	labels := map[string]string{}
+	for k, v := range g.Labels {
+		labels[k] = v
+	}
+	for k, v := range r.Labels {
+		labels[k] = v
+	}
+	severity := defaultSeverity
+	if s, ok := labels[severityLabel]; ok {
+		delete(labels, severityLabel)
+		if mapped, ok := severities[strings.ToLower(s)]; ok {
+			severity = mapped
+		} else {
+			warnings = append(warnings, fmt.Sprintf("unknown severity %q was imported as %s", s, defaultSeverity))
+		}
+	}
+
+	for _, k := range sortedKeys(r.Annotations) {
+		if valueRef.MatchString(r.Annotations[k]) {
+			warnings = append(warnings, fmt.Sprintf("annotation %s uses $value, which is always 1 in the imported Alert", k))
+		}
+	}
+
+	// A Prometheus rule fires for every series its expression returns,
+	// whatever its value. SigNoz compares values with a threshold, so each
+	// returned series is mapped to 1 and compared with 0.
+	target := float64(0)
+	a := &v1beta1.Alert{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   src.namespace,
+			Labels:      map[string]string{LabelPrometheusRule: src.name},
+			Annotations: map[string]string{},
+		},
+		Spec: v1beta1.AlertSpec{
+			ForProvider: v1beta1.AlertParameters{
+				AlertName: r.Alert,
+				AlertType: "METRIC_BASED_ALERT",
+				Condition: v1beta1.RuleCondition{
+					CompositeQuery: v1beta1.CompositeQuery{
+						QueryType: "promql",
+						PromQL:    []v1beta1.AlertPromQuery{{Name: "A", Query: fmt.Sprintf("group without () (%s)", expr)}},
+					},
+					CompareOp:         ">",
+					Target:            &target,
+					MatchType:         &matchType,
+					SelectedQueryName: "A",
+				},
+				EvalWindow:         duration(evalWindow),
+				Frequency:          duration(frequency),
+				Severity:           severity,
+				Labels:             nilIfEmpty(labels),
+				Annotations:        nilIfEmpty(r.Annotations),
+				ChannelIDsSelector: src.selector,
+			},
+		},
+	}
+	a.Annotations[AnnotationRuleHash] = clients.SpecHash(struct {
+		Labels map[string]string
+		Spec   v1beta1.AlertSpec
+	}{a.Labels, a.Spec})
+	return a, warnings, nil
+}
+
+// slug turns an alert name such as KubePodCrashLooping into a DNS label
+// such as kube-pod-crash-looping.
+func slug(s string) string {
+	r := []rune(s)
+	var b strings.Builder
+	for i, c := range r {
+		switch {
+		case isUpper(c):
+			// Start a word at a lower-to-upper change, and at the last
+			// capital of an acronym followed by a word (HTTPError).
+			if i > 0 && (!isUpper(r[i-1]) || i+1 < len(r) && isLower(r[i+1])) {
+				b.WriteByte('-')
+			}
+			b.WriteRune(c + 'a' - 'A')
+		case isLower(c), c >= '0' && c <= '9':
+			b.WriteRune(c)
+		default:
+			b.WriteByte('-')
+		}
+	}
+	return strings.Trim(dashes.ReplaceAllString(b.String(), "-"), "-")
+}
+
+func isUpper(c rune) bool { return c >= 'A' && c <= 'Z' }
+
+func isLower(c rune) bool { return c >= 'a' && c <= 'z' }
+
+// duration formats d in the largest whole unit Go's time.ParseDuration
+// accepts, as SigNoz does not accept days.
+func duration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func nilIfEmpty(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestSlug(t *testing.T) {
+	cases := map[string]string{
+		"KubePodCrashLooping": "kube-pod-crash-looping",
+		"HTTPErrorRateHigh":   "http-error-rate-high",
+		"node_disk_full":      "node-disk-full",
+		"Target Down!":        "target-down",
+		"etcd5xxRate":         "etcd5xx-rate",
+	}
+	for in, want := range cases {
+		if got := slug(in); got != want {
+			t.Errorf("slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	selector := &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "platform"}}
+	spec := ruleSpec{Groups: []ruleGroup{{
+		Name:     "api",
+		Interval: "30s",
+		Labels:   map[string]string{"team": "api"},
+		Rules: []rule{
+			{Record: "job:errors:rate5m", Expr: intstr.FromString("sum by (job) (rate(errors_total[5m]))")},
+			{
+				Alert:       "HighErrorRate",
+				Expr:        intstr.FromString(`job:errors:rate5m > 0.05`),
+				For:         "10m",
+				Labels:      map[string]string{"severity": "page", "service": "api"},
+				Annotations: map[string]string{"summary": "High error rate on {{ $labels.job }}"},
+			},
+			{Alert: "HighErrorRate", Expr: intstr.FromString(`job:errors:rate5m > 0.01`), For: "1d", Labels: map[string]string{"severity": "ticket"}},
+			{Alert: "NoExpr"},
+			{Alert: "BadFor", Expr: intstr.FromString("up == 0"), For: "soon"},
+			{Alert: "KeepFiring", Expr: intstr.FromString("up == 0"), KeepFiringFor: "5m",
+				Annotations: map[string]string{"description": "{{ $value }} targets down"}},
+		},
+	}}}
+
+	got := convert(source{name: "api-rules", namespace: "monitoring", selector: selector}, spec)
+	if len(got) != 5 {
+		t.Fatalf("convert() returned %d conversions, want 5: recording rules are skipped", len(got))
+	}
+
+	page := got[0]
+	if page.Err != nil || len(page.Warnings) != 0 {
+		t.Fatalf("HighErrorRate: err = %v, warnings = %v", page.Err, page.Warnings)
+	}
+	a := page.Alert
+	if a.GetName() != "api-rules-high-error-rate" || a.GetNamespace() != "monitoring" || a.GetLabels()[LabelPrometheusRule] != "api-rules" {
+		t.Errorf("HighErrorRate metadata = %+v", a.ObjectMeta)
+	}
+	if a.GetAnnotations()[AnnotationRuleHash] == "" {
+		t.Error("HighErrorRate has no rule hash")
+	}
+	p := a.Spec.ForProvider
+	if p.AlertName != "HighErrorRate" || p.AlertType != "METRIC_BASED_ALERT" {
+		t.Errorf("alertName, alertType = %s, %s", p.AlertName, p.AlertType)
+	}
+	if got, want := p.Condition.CompositeQuery.PromQL[0].Query, "group without () (job:errors:rate5m > 0.05)"; got != want {
+		t.Errorf("query = %s, want %s", got, want)
+	}
+	if c := p.Condition; c.CompareOp != ">" || *c.Target != 0 || *c.MatchType != 2 || c.SelectedQueryName != "A" {
+		t.Errorf("condition = %s %v, matchType %d, selected %s", c.CompareOp, *c.Target, *c.MatchType, c.SelectedQueryName)
+	}
+	if p.EvalWindow != "10m" || p.Frequency != "30s" || p.Severity != "critical" {
+		t.Errorf("evalWindow, frequency, severity = %s, %s, %s, want 10m, 30s, critical", p.EvalWindow, p.Frequency, p.Severity)
+	}
+	if len(p.Labels) != 2 || p.Labels["team"] != "api" || p.Labels["service"] != "api" {
+		t.Errorf("labels = %v, want group and rule labels without severity", p.Labels)
+	}
+	if p.Annotations["summary"] != "High error rate on {{ $labels.job }}" {
+		t.Errorf("annotations = %v", p.Annotations)
+	}
+	if p.ChannelIDsSelector != selector {
+		t.Errorf("channel selector = %+v, want the default", p.ChannelIDsSelector)
+	}
+
+	// A second rule with the same alert name gets a distinct Alert, and an
+	// unknown severity is imported as a warning.
+	ticket := got[1]
+	if ticket.Err != nil || ticket.Name != "api-rules-high-error-rate-2" {
+		t.Fatalf("second HighErrorRate: name = %q, err = %v", ticket.Name, ticket.Err)
+	}
+	if p := ticket.Alert.Spec.ForProvider; p.Severity != "warning" || p.EvalWindow != "24h" {
+		t.Errorf("second HighErrorRate: severity = %q, evalWindow = %q, want warning, 24h", p.Severity, p.EvalWindow)
+	}
+	if len(ticket.Warnings) != 1 || !strings.Contains(ticket.Warnings[0], `unknown severity "ticket"`) {
+		t.Errorf("second HighErrorRate warnings = %v", ticket.Warnings)
+	}
+
+	// Unconvertible rules keep their name, so their Alerts aren't pruned.
+	for _, c := range got[2:4] {
+		if c.Err == nil || c.Alert != nil || c.Name == "" {
+			t.Errorf("%s: err = %v, alert = %v, name = %q, want an error and a name", c.Rule, c.Err, c.Alert, c.Name)
+		}
+	}
+
+	keep := got[4]
+	if keep.Err != nil || len(keep.Warnings) != 2 {
+		t.Fatalf("KeepFiring: err = %v, warnings = %v, want keep_firing_for and $value warnings", keep.Err, keep.Warnings)
+	}
+	if p := keep.Alert.Spec.ForProvider; *p.Condition.MatchType != 1 || p.EvalWindow != "30s" || p.Severity != "warning" {
+		t.Errorf("KeepFiring: matchType = %d, evalWindow = %q, severity = %q, want 1, 30s, warning",
+			*p.Condition.MatchType, p.EvalWindow, p.Severity)
+	}
+}
+
+func TestParseChannelSelector(t *testing.T) {
+	got, err := ParseChannelSelector("team=platform,tier in (1,2)")
+	if err != nil {
+		t.Fatalf("ParseChannelSelector() error = %v", err)
+	}
+	if got.MatchLabels["team"] != "platform" || len(got.MatchExpressions) != 1 || got.MatchExpressions[0].Key != "tier" {
+		t.Errorf("ParseChannelSelector() = %+v", got)
+	}
+
+	if got, err := ParseChannelSelector(""); got != nil || err != nil {
+		t.Errorf("ParseChannelSelector(\"\") = %v, %v, want nil, nil", got, err)
+	}
+	if _, err := ParseChannelSelector("team in platform"); err == nil {
+		t.Error("ParseChannelSelector() of an invalid selector returned no error")
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package prometheusrule imports Prometheus Operator PrometheusRules as
+// Alerts.
+package prometheusrule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/pkg/errors"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	"github.com/rossigee/provider-signoz/internal/controller/children"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const controllerName = "prometheusrule.alert.signoz.crossplane.io"
+
+// PrometheusRuleGroupVersionKind is the kind imported by this controller.
+// It is read as unstructured data, so the Prometheus Operator API isn't a
+// dependency of the provider.
+var PrometheusRuleGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// Event reasons.
+const (
+	ReasonCannotConvert event.Reason = "CannotConvertRule"
+	ReasonPartialImport event.Reason = "PartiallyImportedRule"
+	ReasonCannotImport  event.Reason = "CannotImportRule"
+)
+
+const (
+	errGetRule    = "cannot get PrometheusRule"
+	errDecodeSpec = "cannot decode PrometheusRule spec"
+)
+
+// Config configures PrometheusRule import.
+type Config struct {
+	// ChannelSelector selects the NotificationChannels imported Alerts
+	// notify, unless their PrometheusRule sets AnnotationChannelSelector.
+	ChannelSelector *v1beta1.ChannelSelector
+}
+
+// Setup adds a controller that imports PrometheusRules labelled with
+// LabelImport. The PrometheusRule CRD must be installed.
+func Setup(mgr ctrl.Manager, o controller.Options, cfg Config) error {
+	r := &reconciler{
+		kube:     mgr.GetClient(),
+		record:   clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(controllerName))),
+		selector: cfg.ChannelSelector,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(o.ForControllerRuntime()).
+		// Label and annotation changes opt rules in and out, and change
+		// their channel selector.
+		For(newPrometheusRule(), builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+			predicate.AnnotationChangedPredicate{}))).
+		Owns(&v1beta1.Alert{}).
+		Complete(r)
+}
+
+type reconciler struct {
+	kube     client.Client
+	record   event.Recorder
+	selector *v1beta1.ChannelSelector
+}
+
+func newPrometheusRule() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+	return u
+}
+
+// Reconcile converts each alerting rule of an opted-in PrometheusRule into
+// an Alert, creates or updates it, and deletes the Alerts of rules that no
+// longer exist. Imported Alerts are controlled by the PrometheusRule, so
+// they are garbage collected with it, and are deleted if it opts out.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	pr := newPrometheusRule()
+	if err := r.kube.Get(ctx, req.NamespacedName, pr); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetRule)
+	}
+	if pr.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+	if pr.GetLabels()[LabelImport] != "true" {
+		return reconcile.Result{}, r.prune(ctx, pr, nil)
+	}
+
+	// Bad input is reported on the PrometheusRule rather than retried; it
+	// is reconciled again once it changes.
+	spec := ruleSpec{}
+	if raw, ok := pr.Object["spec"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &spec); err != nil {
+			r.record.Event(pr, event.Warning(ReasonCannotConvert, errors.Wrap(err, errDecodeSpec)))
+			return reconcile.Result{}, nil
+		}
+	}
+	selector := r.selector
+	if s, ok := pr.GetAnnotations()[AnnotationChannelSelector]; ok {
+		sel, err := ParseChannelSelector(s)
+		if err != nil {
+			r.record.Event(pr, event.Warning(ReasonCannotConvert, err))
+			return reconcile.Result{}, nil
+		}
+		selector = sel
+	}
+
+	keep := map[string]bool{}
+	for _, c := range convert(source{name: pr.GetName(), namespace: pr.GetNamespace(), selector: selector}, spec) {
+		if c.Name != "" {
+			keep[c.Name] = true
+		}
+		if c.Err != nil {
+			r.record.Event(pr, event.Warning(ReasonCannotConvert, errors.Wrap(c.Err, describe(c)),
+				"group", c.Group, "alert", c.Rule))
+			continue
+		}
+		for _, w := range c.Warnings {
+			r.record.Event(pr, event.Normal(ReasonPartialImport, fmt.Sprintf("%s: %s", describe(c), w),
+				"group", c.Group, "alert", c.Rule))
+		}
+		if err := r.apply(ctx, pr, c.Alert); err != nil {
+			if !errors.Is(err, children.ErrNotControlled) {
+				return reconcile.Result{}, err
+			}
+			r.record.Event(pr, event.Warning(ReasonCannotImport, errors.Wrap(err, describe(c)),
+				"group", c.Group, "alert", c.Rule))
+		}
+	}
+	return reconcile.Result{}, r.prune(ctx, pr, keep)
+}
+
+// apply creates or updates an imported Alert.
+func (r *reconciler) apply(ctx context.Context, pr *unstructured.Unstructured, desired *v1beta1.Alert) error {
+	desired.SetOwnerReferences([]metav1.OwnerReference{*metav1.NewControllerRef(pr, PrometheusRuleGroupVersionKind)})
+	existing := &v1beta1.Alert{}
+	_, err := children.Apply(ctx, r.kube, pr, AnnotationRuleHash, desired, existing, func() {
+		existing.Spec.ForProvider = desired.Spec.ForProvider
+	})
+	return err
+}
+
+// prune deletes Alerts controlled by the PrometheusRule that aren't kept.
+func (r *reconciler) prune(ctx context.Context, pr *unstructured.Unstructured, keep map[string]bool) error {
+	return children.Prune(ctx, r.kube, pr, &v1beta1.AlertList{}, keep,
+		client.InNamespace(pr.GetNamespace()), client.MatchingLabels{LabelPrometheusRule: pr.GetName()})
+}
+
+func describe(c conversion) string {
+	return fmt.Sprintf("rule %s in group %s", c.Rule, c.Group)
+}
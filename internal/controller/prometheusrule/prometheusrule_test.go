@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package prometheusrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type recordedEvents struct {
+	events []event.Event
+}
+
+func (r *recordedEvents) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *recordedEvents) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func (r *recordedEvents) count(reason event.Reason) int {
+	n := 0
+	for _, e := range r.events {
+		if e.Reason == reason {
+			n++
+		}
+	}
+	return n
+}
+
+func newFakeKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	if err := v1beta1.SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("cannot add alert types to scheme: %v", err)
+	}
+	s.AddKnownTypeWithName(PrometheusRuleGroupVersionKind, &unstructured.Unstructured{})
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+}
+
+func prometheusRule(rules ...interface{}) *unstructured.Unstructured {
+	pr := newPrometheusRule()
+	pr.SetName("api")
+	pr.SetNamespace("monitoring")
+	pr.SetUID("uid-api")
+	pr.SetLabels(map[string]string{LabelImport: "true"})
+	pr.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{map[string]interface{}{"name": "api", "rules": rules}},
+	}
+	return pr
+}
+
+func reconcileRule(t *testing.T, r *reconciler) {
+	t.Helper()
+	key := types.NamespacedName{Namespace: "monitoring", Name: "api"}
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+}
+
+func getAlert(t *testing.T, kube client.Client, name string) (*v1beta1.Alert, error) {
+	t.Helper()
+	a := &v1beta1.Alert{}
+	err := kube.Get(context.Background(), types.NamespacedName{Namespace: "monitoring", Name: name}, a)
+	return a, err
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	down := map[string]interface{}{"alert": "TargetDown", "expr": "up == 0", "for": "5m", "labels": map[string]interface{}{"severity": "critical"}}
+	slow := map[string]interface{}{"alert": "SlowRequests", "expr": "histogram_quantile(0.99, rate(http_duration_seconds_bucket[5m])) > 1"}
+	broken := map[string]interface{}{"alert": "Broken", "expr": "up == 0", "for": "eventually"}
+	pr := prometheusRule(down, slow, broken, map[string]interface{}{"record": "job:up:sum", "expr": "sum by (job) (up)"})
+	kube := newFakeKube(t, pr)
+	rec := &recordedEvents{}
+	selector := &v1beta1.ChannelSelector{MatchLabels: map[string]string{"team": "api"}}
+	r := &reconciler{kube: kube, record: rec, selector: selector}
+
+	// Each convertible alerting rule gets an Alert controlled by the
+	// PrometheusRule, and the unconvertible one is reported.
+	reconcileRule(t, r)
+	for _, name := range []string{"api-target-down", "api-slow-requests"} {
+		a, err := getAlert(t, kube, name)
+		if err != nil {
+			t.Fatalf("expected Alert %s: %v", name, err)
+		}
+		if !metav1.IsControlledBy(a, pr) {
+			t.Errorf("Alert %s is not controlled by the PrometheusRule", name)
+		}
+		if a.Spec.ForProvider.ChannelIDsSelector == nil || a.Spec.ForProvider.ChannelIDsSelector.MatchLabels["team"] != "api" {
+			t.Errorf("Alert %s channel selector = %+v, want the default", name, a.Spec.ForProvider.ChannelIDsSelector)
+		}
+	}
+	if _, err := getAlert(t, kube, "api-broken"); !kerrors.IsNotFound(err) {
+		t.Errorf("unconvertible rule produced an Alert: %v", err)
+	}
+	if n := rec.count(ReasonCannotConvert); n != 1 {
+		t.Errorf("%d %s events, want 1", n, ReasonCannotConvert)
+	}
+
+	// The channel selector annotation overrides the default, and changes
+	// keep the Alert's external name.
+	a, _ := getAlert(t, kube, "api-target-down")
+	meta.SetExternalName(a, "42")
+	if err := kube.Update(ctx, a); err != nil {
+		t.Fatalf("cannot set external name: %v", err)
+	}
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(pr), pr); err != nil {
+		t.Fatalf("cannot get PrometheusRule: %v", err)
+	}
+	pr.SetAnnotations(map[string]string{AnnotationChannelSelector: "team=sre"})
+	pr.Object["spec"] = prometheusRule(down).Object["spec"]
+	if err := kube.Update(ctx, pr); err != nil {
+		t.Fatalf("cannot update PrometheusRule: %v", err)
+	}
+	reconcileRule(t, r)
+	a, _ = getAlert(t, kube, "api-target-down")
+	if a.Spec.ForProvider.ChannelIDsSelector.MatchLabels["team"] != "sre" {
+		t.Errorf("channel selector = %+v, want the annotation's", a.Spec.ForProvider.ChannelIDsSelector)
+	}
+	if a.GetAnnotations()["crossplane.io/external-name"] != "42" {
+		t.Error("update dropped the Alert's external name")
+	}
+
+	// Removed rules have their Alerts deleted.
+	if _, err := getAlert(t, kube, "api-slow-requests"); !kerrors.IsNotFound(err) {
+		t.Errorf("Alert of removed rule still exists: %v", err)
+	}
+
+	// Opting out deletes every imported Alert.
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(pr), pr); err != nil {
+		t.Fatalf("cannot get PrometheusRule: %v", err)
+	}
+	pr.SetLabels(nil)
+	if err := kube.Update(ctx, pr); err != nil {
+		t.Fatalf("cannot update PrometheusRule: %v", err)
+	}
+	reconcileRule(t, r)
+	if _, err := getAlert(t, kube, "api-target-down"); !kerrors.IsNotFound(err) {
+		t.Errorf("Alert of opted-out PrometheusRule still exists: %v", err)
+	}
+}
+
+func TestReconcileNotControlled(t *testing.T) {
+	existing := &v1beta1.Alert{ObjectMeta: metav1.ObjectMeta{Name: "api-target-down", Namespace: "monitoring"}}
+	kube := newFakeKube(t, prometheusRule(map[string]interface{}{"alert": "TargetDown", "expr": "up == 0"}), existing)
+	rec := &recordedEvents{}
+	reconcileRule(t, &reconciler{kube: kube, record: rec})
+
+	if n := rec.count(ReasonCannotImport); n != 1 {
+		t.Errorf("%d %s events, want 1", n, ReasonCannotImport)
+	}
+	a, _ := getAlert(t, kube, "api-target-down")
+	if a.Spec.ForProvider.AlertName != "" {
+		t.Error("Alert not controlled by the PrometheusRule was overwritten")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/hex"
 	stderrors "errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,8 +32,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
 
 	"github.com/rossigee/provider-signoz/apis/v1beta1"
@@ -57,8 +62,13 @@ const (
 	ReasonUpstreamTransient   = "UpstreamTransient"
 	ReasonSecretMissing       = "SecretMissing"
 	ReasonEndpointUnreachable = "EndpointUnreachable"
+	ReasonCertificateInvalid  = "CertificateInvalid"
 )
 
+// ReasonSecondaryAPIKeyActive is the reason of the event recorded when the
+// credentials' apiKey was rejected and their nextApiKey accepted.
+const ReasonSecondaryAPIKeyActive event.Reason = "SecondaryAPIKeyActive"
+
 // Backoff constants. Requeue intervals come from clients.BackoffPolicy; these
 // are absolute safety nets for error paths where err==nil (e.g. status-write
 // failures).
@@ -98,11 +108,27 @@ func SetupWithConfig(mgr ctrl.Manager, cfg ReconcilerConfig) error {
 		kube:    mgr.GetClient(),
 		logger:  cfg.Logger,
 		cfg:     cfg,
+		record:  clients.NewRedactingRecorder(event.NewAPIRecorder(mgr.GetEventRecorder(controllerName))),
 		failCnt: make(map[string]int),
 	}
+
+	// A managed resource's client falling back to the credentials'
+	// nextApiKey requeues its ProviderConfig, so the status and event
+	// don't wait for the next probe.
+	fallbacks := make(chan ctrlevent.GenericEvent, 16)
+	clients.SetAPIKeyFallbackHandler(func(providerConfig string) {
+		ns, name, _ := strings.Cut(providerConfig, "/")
+		pc := &v1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}}
+		select {
+		case fallbacks <- ctrlevent.GenericEvent{Object: pc}:
+		default:
+		}
+	})
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(controllerName).
 		For(&v1beta1.ProviderConfig{}).
+		WatchesRawSource(source.Channel(fallbacks, &handler.EnqueueRequestForObject{})).
 		Complete(r)
 }
 
@@ -110,6 +136,7 @@ type reconciler struct {
 	kube   client.Client
 	logger logr.Logger
 	cfg    ReconcilerConfig
+	record event.Recorder
 
 	// failCnt tracks consecutive credentials-probe failures per ProviderConfig.
 	// Keyed by namespaced name. Reset on a successful probe.
@@ -135,8 +162,12 @@ func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	pc := &v1beta1.ProviderConfig{}
 	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		if k8serrors.IsNotFound(err) {
+			clients.DefaultClientCache.Invalidate(req.String())
+			return reconcile.Result{}, nil
+		}
 		log.Error(err, "failed to get ProviderConfig")
-		return reconcile.Result{}, client.IgnoreNotFound(err)
+		return reconcile.Result{}, err
 	}
 
 	// Build a client from the ProviderConfig and probe credentials. We
@@ -152,10 +183,12 @@ func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return r.updateStatusAndRequeue(ctx, pc, log, err)
 	}
 
-	c := clients.NewClient(*cfg)
-	if err := c.ProbeCredentials(probeCtx); err != nil {
+	c := clients.CachedClient(*cfg)
+	err = c.ProbeCredentials(probeCtx)
+	r.recordActiveAPIKey(pc, c.ActiveAPIKey())
+	if err != nil {
 		log.Error(err, "credentials probe failed",
-			"endpoint", cfg.BaseURL,
+			"endpoint", clients.RedactURL(cfg.BaseURL),
 			"fingerprint", fingerprintKey(cfg.BaseURL, cfg.APIKey))
 		r.recordFailure(req.String())
 		pc.Status.SetConditions(invalidCredentialsCondition(err, r.cfg.ConnTimeout))
@@ -164,7 +197,8 @@ func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	// Probe succeeded.
 	r.recordSuccess(req.String())
-	log.Info("ProviderConfig credentials verified", "endpoint", cfg.BaseURL)
+	log.Info("ProviderConfig credentials verified", "endpoint", clients.RedactURL(cfg.BaseURL))
+	recordCapabilities(probeCtx, pc, c, log)
 	pc.Status.SetConditions(xpv1.Available())
 	pc.Status.SetConditions(xpv1.Condition{
 		Type:               TypeCredentialsValid,
@@ -192,7 +226,7 @@ func (r *reconciler) updateStatusAndRequeue(ctx context.Context, pc *v1beta1.Pro
 	class, delay := clients.Backoff(err, failures, r.cfg.BackoffPolicy)
 	log.Info("ProviderConfig credentials probe failed; requeueing",
 		"consecutive_failures", failures,
-		"class", classString(class),
+		"class", class.String(),
 		"in", delay.String())
 	if delay <= 0 {
 		delay = authRequeueMin
@@ -200,16 +234,33 @@ func (r *reconciler) updateStatusAndRequeue(ctx context.Context, pc *v1beta1.Pro
 	return reconcile.Result{RequeueAfter: delay}, nil
 }
 
-func classString(c clients.BackoffClass) string {
-	switch c {
-	case clients.ClassAuth:
-		return "auth"
-	case clients.ClassTransient:
-		return "transient"
-	case clients.ClassRateLimited:
-		return "rate-limited"
-	default:
-		return "none"
+// recordActiveAPIKey sets the ProviderConfig's active API key, and records
+// an event when its apiKey was rejected and its nextApiKey accepted.
+func (r *reconciler) recordActiveAPIKey(pc *v1beta1.ProviderConfig, active v1beta1.APIKeySlot) {
+	prev := pc.Status.ActiveAPIKey
+	pc.Status.ActiveAPIKey = active
+	if active != v1beta1.APIKeySlotSecondary || prev == v1beta1.APIKeySlotSecondary {
+		return
+	}
+	r.record.Event(pc, event.Warning(ReasonSecondaryAPIKeyActive,
+		stderrors.New("SigNoz rejected the credentials' apiKey and accepted their nextApiKey: promote nextApiKey to apiKey")))
+}
+
+// recordCapabilities sets the ProviderConfig's server version and feature
+// flags. If SigNoz doesn't report them the previous ones are kept: they
+// only select payload schemas, and don't make the credentials invalid.
+func recordCapabilities(ctx context.Context, pc *v1beta1.ProviderConfig, c *clients.Client, log logr.Logger) {
+	caps, err := c.DetectCapabilities(ctx)
+	if err != nil {
+		log.Info("Cannot detect SigNoz version; keeping the last known capabilities", "error", err.Error())
+		return
+	}
+	if caps.Version != pc.Status.ServerVersion {
+		log.Info("Detected SigNoz version", "version", caps.Version)
+	}
+	pc.Status.ServerVersion = caps.Version
+	if caps.Features != nil {
+		pc.Status.FeatureFlags = caps.Features
 	}
 }
 
@@ -257,6 +308,9 @@ func invalidCredentialsCondition(err error, probeTimeout time.Duration) xpv1.Con
 	}
 
 	switch {
+	case clients.IsCertificateError(err):
+		c.Reason = ReasonCertificateInvalid
+		c.Message = fmt.Sprintf("TLS certificate error: %v", err)
 	case stderrors.Is(err, clients.ErrAuth):
 		c.Reason = ReasonCredentialsRejected
 		c.Message = fmt.Sprintf("Upstream rejected credentials: %v", err)
@@ -273,7 +327,7 @@ func invalidCredentialsCondition(err error, probeTimeout time.Duration) xpv1.Con
 			c.Message = "Credentials source exists but is not valid JSON or is missing the apiKey field"
 		default:
 			switch {
-			case contains(err.Error(), "apiKey"):
+			case contains(err.Error(), "apiKey") || contains(err.Error(), "email or password"):
 				if contains(err.Error(), "empty") || contains(err.Error(), "missing") {
 					c.Reason = ReasonCredentialsEmpty
 				} else {
@@ -289,6 +343,7 @@ func invalidCredentialsCondition(err error, probeTimeout time.Duration) xpv1.Con
 			}
 		}
 	}
+	c.Message = clients.RedactString(c.Message)
 	return c
 }
 
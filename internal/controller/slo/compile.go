@@ -0,0 +1,337 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	dashboardv1beta1 "github.com/rossigee/provider-signoz/apis/dashboard/v1beta1"
+	"github.com/rossigee/provider-signoz/apis/slo/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationSpecHash holds the hash of the spec and metadata an Alert or
+	// Dashboard was last generated with, so it is only updated when the
+	// ServiceLevelObjective changes.
+	AnnotationSpecHash = "signoz.m.crossplane.io/slo-hash"
+
+	// LabelSLO is set on generated Alerts and Dashboards to the name of
+	// their ServiceLevelObjective.
+	LabelSLO = "signoz.m.crossplane.io/slo"
+
+	// windowPlaceholder is replaced with the evaluated range in PromQL
+	// indicator queries.
+	windowPlaceholder = "${window}"
+
+	defaultWindow = "30d"
+)
+
+const (
+	errIndicator      = "indicator.promQL must be set"
+	errNoWindowVar    = "PromQL indicator queries must contain " + windowPlaceholder
+	errInvalidWindow  = "invalid window"
+	errWindowTooShort = "window must be longer than the slow-burn window of 6h"
+	errInvalidTarget  = "target must be between 0 and 100"
+)
+
+// A burnTier is one of the multi-window, multi-burn-rate alerts: it fires
+// when the error budget burns fast enough over the long window to spend
+// budgetSpent of it, and is still burning over the short window.
+type burnTier struct {
+	name        string
+	long, short time.Duration
+	budgetSpent float64
+}
+
+// tiers are the standard page (2% of the budget in an hour) and ticket (5%
+// in six hours) burn-rate alerts. For a 30d window their burn rates are 14.4
+// and 6.
+var tiers = []burnTier{
+	{name: "fast", long: time.Hour, short: 5 * time.Minute, budgetSpent: 0.02},
+	{name: "slow", long: 6 * time.Hour, short: 30 * time.Minute, budgetSpent: 0.05},
+}
+
+// burnRate is the burn rate at which the tier spends its share of the
+// budget of the given window.
+func (t burnTier) burnRate(window time.Duration) float64 {
+	return round(t.budgetSpent * float64(window) / float64(t.long))
+}
+
+// objective is a validated ServiceLevelObjective.
+type objective struct {
+	slo    *v1beta1.ServiceLevelObjective
+	window time.Duration
+	budget float64
+}
+
+func newObjective(slo *v1beta1.ServiceLevelObjective) (*objective, error) {
+	s := slo.Spec
+	if s.Target <= 0 || s.Target >= 100 {
+		return nil, errors.New(errInvalidTarget)
+	}
+	w := s.Window
+	if w == "" {
+		w = defaultWindow
+	}
+	window, err := parseWindow(w)
+	if err != nil {
+		return nil, err
+	}
+	if window <= tiers[len(tiers)-1].long {
+		return nil, errors.New(errWindowTooShort)
+	}
+
+	ind := s.Indicator
+	switch {
+	case ind.PromQL == nil:
+		return nil, errors.New(errIndicator)
+	case !strings.Contains(ind.PromQL.Good, windowPlaceholder) || !strings.Contains(ind.PromQL.Total, windowPlaceholder):
+		return nil, errors.New(errNoWindowVar)
+	}
+	return &objective{slo: slo, window: window, budget: round((100 - s.Target) / 100)}, nil
+}
+
+// parseWindow parses a window of whole hours or days, e.g. 30d.
+func parseWindow(w string) (time.Duration, error) {
+	if len(w) < 2 {
+		return 0, errors.Errorf("%s %q", errInvalidWindow, w)
+	}
+	n, err := strconv.Atoi(w[:len(w)-1])
+	if err != nil || n <= 0 {
+		return 0, errors.Errorf("%s %q", errInvalidWindow, w)
+	}
+	switch w[len(w)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return 0, errors.Errorf("%s %q", errInvalidWindow, w)
+}
+
+// promDuration formats a duration the way PromQL range selectors and
+// SigNoz evaluation windows write it: 30d, 6h, 5m.
+func promDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	}
+	return fmt.Sprintf("%dm", d/time.Minute)
+}
+
+// round drops floating point noise, so a 99.9% target has a budget of
+// exactly 0.001.
+func round(v float64) float64 {
+	r, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 10, 64), 64)
+	return r
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func (o *objective) displayName() string {
+	if o.slo.Spec.DisplayName != "" {
+		return o.slo.Spec.DisplayName
+	}
+	return o.slo.GetName()
+}
+
+// promRatio is the PromQL error ratio over the window w.
+func (o *objective) promRatio(w time.Duration) string {
+	p := o.slo.Spec.Indicator.PromQL
+	r := promDuration(w)
+	return fmt.Sprintf("1 - (%s) / (%s)",
+		strings.ReplaceAll(p.Good, windowPlaceholder, r),
+		strings.ReplaceAll(p.Total, windowPlaceholder, r))
+}
+
+// burn turns an error ratio expression into a burn rate expression.
+func (o *objective) burn(ratio string) string {
+	return fmt.Sprintf("(%s) / %s", ratio, formatFloat(o.budget))
+}
+
+// remaining turns an error ratio expression into the percentage of error
+// budget left.
+func (o *objective) remaining(ratio string) string {
+	return fmt.Sprintf("(1 - (%s) / %s) * 100", ratio, formatFloat(o.budget))
+}
+
+// query returns a composite query evaluating expr, written in terms of the
+// error ratio, over the window w, and the name of the query holding the
+// result.
+func (o *objective) query(w time.Duration, expr func(ratio string) string) (alertv1beta1.CompositeQuery, string) {
+	return alertv1beta1.CompositeQuery{
+		QueryType: "promql",
+		PromQL:    []alertv1beta1.AlertPromQuery{{Name: "A", Query: expr(o.promRatio(w))}},
+	}, "A"
+}
+
+func (o *objective) severity(t burnTier) string {
+	a := o.slo.Spec.Alerting
+	switch {
+	case t.name == "fast" && a.FastBurnSeverity != "":
+		return a.FastBurnSeverity
+	case t.name == "slow" && a.SlowBurnSeverity != "":
+		return a.SlowBurnSeverity
+	case t.name == "fast":
+		return "critical"
+	}
+	return "warning"
+}
+
+// metadata returns the metadata of a generated object. The owner reference
+// and hash are added by the caller.
+func (o *objective) metadata(name string, labels map[string]string) metav1.ObjectMeta {
+	l := map[string]string{LabelSLO: o.slo.GetName()}
+	for k, v := range labels {
+		l[k] = v
+	}
+	return metav1.ObjectMeta{
+		Name:            name,
+		Namespace:       o.slo.GetNamespace(),
+		Labels:          l,
+		Annotations:     map[string]string{},
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(o.slo, v1beta1.ServiceLevelObjective_GroupVersionKind)},
+	}
+}
+
+// hash records the hash of an object's labels and spec in its annotations.
+func hash(m *metav1.ObjectMeta, spec interface{}) {
+	m.Annotations[AnnotationSpecHash] = clients.SpecHash(struct {
+		Labels map[string]string
+		Spec   interface{}
+	}{m.Labels, spec})
+}
+
+func alertName(slo *v1beta1.ServiceLevelObjective, t burnTier) string {
+	return slo.GetName() + "-" + t.name + "-burn"
+}
+
+func dashboardName(slo *v1beta1.ServiceLevelObjective) string {
+	return slo.GetName() + "-error-budget"
+}
+
+// alerts returns the burn-rate Alerts of the objective.
+func (o *objective) alerts() []*alertv1beta1.Alert {
+	out := make([]*alertv1beta1.Alert, 0, len(tiers))
+	for _, t := range tiers {
+		rate := t.burnRate(o.window)
+		// Both windows must burn: the long one to be significant, the short
+		// one so the alert resolves soon after the burn stops.
+		query := alertv1beta1.CompositeQuery{
+			QueryType: "promql",
+			PromQL: []alertv1beta1.AlertPromQuery{{Name: "A", Query: fmt.Sprintf("%s > %s and %s > %s",
+				o.burn(o.promRatio(t.long)), formatFloat(rate),
+				o.burn(o.promRatio(t.short)), formatFloat(rate))}},
+		}
+
+		labels := map[string]string{"slo": o.slo.GetName(), "burn_window": promDuration(t.long)}
+		for k, v := range o.slo.Spec.Alerting.Labels {
+			labels[k] = v
+		}
+		a := &alertv1beta1.Alert{
+			ObjectMeta: o.metadata(alertName(o.slo, t), map[string]string{"signoz.m.crossplane.io/burn-rate": t.name}),
+			Spec: alertv1beta1.AlertSpec{
+				ManagedResourceSpec: xpv1.ManagedResourceSpec{ProviderConfigReference: o.slo.Spec.ProviderConfigReference},
+				ForProvider: alertv1beta1.AlertParameters{
+					AlertName: fmt.Sprintf("%s: %s error budget burn", o.displayName(), t.name),
+					AlertType: "METRIC_BASED_ALERT",
+					Condition: alertv1beta1.RuleCondition{
+						CompositeQuery:    query,
+						CompareOp:         ">",
+						Target:            &rate,
+						MatchType:         ptrTo(1),
+						SelectedQueryName: "A",
+					},
+					EvalWindow: promDuration(t.short),
+					Frequency:  promDuration(time.Minute),
+					Severity:   o.severity(t),
+					Labels:     labels,
+					Annotations: map[string]string{
+						"summary": fmt.Sprintf("%s is burning its error budget %sx too fast", o.displayName(), formatFloat(rate)),
+						"description": fmt.Sprintf("Over the last %s, %s has spent error budget at %sx the sustainable rate for its %s%% target over %s: %s%% of the budget every %s.",
+							promDuration(t.long), o.displayName(), formatFloat(rate), formatFloat(o.slo.Spec.Target), promDuration(o.window),
+							formatFloat(t.budgetSpent*100), promDuration(t.long)),
+					},
+					ChannelIDsRef:      o.slo.Spec.Alerting.ChannelIDsRef,
+					ChannelIDsSelector: o.slo.Spec.Alerting.ChannelIDsSelector,
+				},
+			},
+		}
+		hash(&a.ObjectMeta, a.Spec)
+		out = append(out, a)
+	}
+	return out
+}
+
+// dashboard returns the error budget Dashboard of the objective.
+func (o *objective) dashboard() *dashboardv1beta1.Dashboard {
+	promQuery := func(name, legend, query string) dashboardv1beta1.PromQuery {
+		return dashboardv1beta1.PromQuery{Name: ptrTo(name), Legend: ptrTo(legend), Query: query}
+	}
+	widget := func(id, title, unit string, queries ...dashboardv1beta1.PromQuery) dashboardv1beta1.Widget {
+		return dashboardv1beta1.Widget{
+			ID:        id,
+			Title:     title,
+			PanelType: "graph",
+			YAxisUnit: ptrTo(unit),
+			Query:     dashboardv1beta1.Query{QueryType: "1", PromQL: queries},
+		}
+	}
+
+	burnQueries := make([]dashboardv1beta1.PromQuery, 0, len(tiers))
+	for _, t := range tiers {
+		w := promDuration(t.long)
+		burnQueries = append(burnQueries, promQuery("burn_"+w, w+" burn rate", o.burn(o.promRatio(t.long))))
+	}
+	description := fmt.Sprintf("Error budget of the %s%% target over %s.", formatFloat(o.slo.Spec.Target), promDuration(o.window))
+
+	d := &dashboardv1beta1.Dashboard{
+		ObjectMeta: o.metadata(dashboardName(o.slo), nil),
+		Spec: dashboardv1beta1.DashboardSpec{
+			ManagedResourceSpec: xpv1.ManagedResourceSpec{ProviderConfigReference: o.slo.Spec.ProviderConfigReference},
+			ForProvider: dashboardv1beta1.DashboardParameters{
+				Title:       o.displayName() + " error budget",
+				Description: &description,
+				Tags:        []string{"slo"},
+				Widgets: []dashboardv1beta1.Widget{
+					widget("error-budget-remaining", "Error budget remaining", "percent",
+						promQuery("remaining", "Remaining", o.remaining(o.promRatio(o.window)))),
+					widget("burn-rate", "Burn rate", "none", burnQueries...),
+					widget("sli", "SLI", "percent",
+						promQuery("sli", "SLI", fmt.Sprintf("(1 - (%s)) * 100", o.promRatio(5*time.Minute))),
+						promQuery("target", "Target", fmt.Sprintf("vector(%s)", formatFloat(o.slo.Spec.Target)))),
+				},
+			},
+		},
+	}
+	hash(&d.ObjectMeta, d.Spec)
+	return d
+}
+
+func ptrTo[T any](v T) *T { return &v }
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rossigee/provider-signoz/apis/slo/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// availability returns a 99.9% availability objective over 30 days.
+func availability() *v1beta1.ServiceLevelObjective {
+	return &v1beta1.ServiceLevelObjective{
+		ObjectMeta: metav1.ObjectMeta{Name: "checkout", Namespace: "monitoring", UID: "slo-uid"},
+		Spec: v1beta1.ServiceLevelObjectiveSpec{
+			DisplayName: "Checkout availability",
+			Target:      99.9,
+			Window:      "30d",
+			Indicator: v1beta1.Indicator{PromQL: &v1beta1.PromQLIndicator{
+				Good:  `sum(rate(http_requests_total{service="checkout",code!~"5.."}[${window}]))`,
+				Total: `sum(rate(http_requests_total{service="checkout"}[${window}]))`,
+			}},
+		},
+	}
+}
+
+func TestNewObjective(t *testing.T) {
+	o, err := newObjective(availability())
+	if err != nil {
+		t.Fatalf("newObjective() error = %v", err)
+	}
+	if o.window != 30*24*time.Hour || o.budget != 0.001 {
+		t.Errorf("window, budget = %s, %v, want 720h, 0.001", o.window, o.budget)
+	}
+	if fast, slow := tiers[0].burnRate(o.window), tiers[1].burnRate(o.window); fast != 14.4 || slow != 6 {
+		t.Errorf("burn rates = %v, %v, want 14.4, 6", fast, slow)
+	}
+
+	for name, mutate := range map[string]func(*v1beta1.ServiceLevelObjective){
+		"NoIndicator": func(s *v1beta1.ServiceLevelObjective) { s.Spec.Indicator.PromQL = nil },
+		"NoWindowVar": func(s *v1beta1.ServiceLevelObjective) { s.Spec.Indicator.PromQL.Good = "sum(up)" },
+		"ShortWindow": func(s *v1beta1.ServiceLevelObjective) { s.Spec.Window = "6h" },
+		"BadWindow":   func(s *v1beta1.ServiceLevelObjective) { s.Spec.Window = "4w" },
+		"BadTarget":   func(s *v1beta1.ServiceLevelObjective) { s.Spec.Target = 100 },
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := availability()
+			mutate(s)
+			if _, err := newObjective(s); err == nil {
+				t.Error("newObjective() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestPromQLAlerts(t *testing.T) {
+	o, _ := newObjective(availability())
+	alerts := o.alerts()
+	if len(alerts) != 2 {
+		t.Fatalf("got %d alerts, want 2", len(alerts))
+	}
+
+	fast := alerts[0]
+	if fast.GetName() != "checkout-fast-burn" || !metav1.IsControlledBy(fast, o.slo) {
+		t.Errorf("fast-burn Alert %s not named or owned as expected", fast.GetName())
+	}
+	p := fast.Spec.ForProvider
+	want := `(1 - (sum(rate(http_requests_total{service="checkout",code!~"5.."}[1h]))) / (sum(rate(http_requests_total{service="checkout"}[1h])))) / 0.001 > 14.4` +
+		` and (1 - (sum(rate(http_requests_total{service="checkout",code!~"5.."}[5m]))) / (sum(rate(http_requests_total{service="checkout"}[5m])))) / 0.001 > 14.4`
+	if got := p.Condition.CompositeQuery.PromQL[0].Query; got != want {
+		t.Errorf("fast-burn query =\n%s\nwant\n%s", got, want)
+	}
+	if *p.Condition.Target != 14.4 || p.EvalWindow != "5m" || p.Severity != "critical" {
+		t.Errorf("fast-burn target, evalWindow, severity = %v, %s, %s", *p.Condition.Target, p.EvalWindow, p.Severity)
+	}
+
+	slow := alerts[1].Spec.ForProvider
+	if !strings.Contains(slow.Condition.CompositeQuery.PromQL[0].Query, "[6h]") || *slow.Condition.Target != 6 || slow.Severity != "warning" {
+		t.Errorf("slow-burn alert = %+v", slow)
+	}
+
+	if d := o.dashboard(); len(d.Spec.ForProvider.Widgets) != 3 {
+		t.Error("expected an error budget dashboard with three widgets")
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	o, _ := newObjective(availability())
+	a := o.alerts()
+	s := availability()
+	s.Spec.Target = 99.5
+	o2, _ := newObjective(s)
+	b := o2.alerts()
+	if a[0].GetAnnotations()[AnnotationSpecHash] == b[0].GetAnnotations()[AnnotationSpecHash] {
+		t.Error("expected a changed target to change the hash")
+	}
+}
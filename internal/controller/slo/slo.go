@@ -0,0 +1,280 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slo compiles ServiceLevelObjectives into burn-rate Alerts and an
+// error budget Dashboard.
+package slo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	dashboardv1beta1 "github.com/rossigee/provider-signoz/apis/dashboard/v1beta1"
+	"github.com/rossigee/provider-signoz/apis/slo/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	"github.com/rossigee/provider-signoz/internal/controller/alert"
+	"github.com/rossigee/provider-signoz/internal/controller/children"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const controllerName = "slo.signoz.crossplane.io"
+
+// TypeBudgetObserved reports whether the burn rate and error budget in
+// status could be queried from SigNoz.
+const TypeBudgetObserved xpv1.ConditionType = "BudgetObserved"
+
+const (
+	ReasonBudgetQueried = "BudgetQueried"
+	ReasonQueryFailed   = "QueryFailed"
+)
+
+const (
+	errGetSLO        = "cannot get ServiceLevelObjective"
+	errUpdateStatus  = "cannot update ServiceLevelObjective status"
+	errQueryBurnRate = "cannot query burn rate"
+	errQueryBudget   = "cannot query error budget"
+)
+
+// A querier runs scalar queries against SigNoz.
+type querier interface {
+	QueryScalar(ctx context.Context, req *clients.QueryRangeRequest, queryName string) (float64, error)
+}
+
+// A child is a resource generated for a ServiceLevelObjective.
+type child interface {
+	client.Object
+	GetCondition(ct xpv1.ConditionType) xpv1.Condition
+}
+
+// Setup adds a controller that reconciles ServiceLevelObjectives.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	r := &reconciler{
+		kube:         mgr.GetClient(),
+		newQuerier:   newQuerier,
+		pollInterval: o.PollInterval,
+		now:          time.Now,
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		WithOptions(o.ForControllerRuntime()).
+		For(&v1beta1.ServiceLevelObjective{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&alertv1beta1.Alert{}).
+		Owns(&dashboardv1beta1.Dashboard{}).
+		Complete(r)
+}
+
+func newQuerier(ctx context.Context, kube client.Client, slo *v1beta1.ServiceLevelObjective) (querier, error) {
+	cfg, err := clients.GetConfigByReference(ctx, kube, slo.GetNamespace(), slo.Spec.ProviderConfigReference)
+	if err != nil {
+		return nil, err
+	}
+	return clients.CachedClient(*cfg), nil
+}
+
+type reconciler struct {
+	kube         client.Client
+	newQuerier   func(ctx context.Context, kube client.Client, slo *v1beta1.ServiceLevelObjective) (querier, error)
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// Reconcile generates the objective's Alerts and Dashboard, deletes those it
+// no longer needs, and reports its burn rate and remaining error budget.
+// Generated resources are controlled by the objective, so they are garbage
+// collected with it.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	slo := &v1beta1.ServiceLevelObjective{}
+	if err := r.kube.Get(ctx, req.NamespacedName, slo); err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetSLO)
+	}
+	if slo.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	o, err := newObjective(slo)
+	if err != nil {
+		// An invalid spec won't fix itself; wait for it to change.
+		slo.Status.SetConditions(xpv1.ReconcileError(err), xpv1.Unavailable().WithMessage(err.Error()))
+		return reconcile.Result{}, r.updateStatus(ctx, slo, nil)
+	}
+	alerts := o.alerts()
+	d := o.dashboard()
+
+	children := make([]child, 0, len(alerts)+1)
+	slo.Status.Alerts = nil
+	for _, a := range alerts {
+		children = append(children, a)
+		slo.Status.Alerts = append(slo.Status.Alerts, a.GetName())
+	}
+	children = append(children, d)
+	slo.Status.Dashboard = d.GetName()
+
+	keep := map[string]bool{}
+	var pending []string
+	for _, c := range children {
+		keep[c.GetName()] = true
+		ready, err := r.apply(ctx, slo, c)
+		if err != nil {
+			slo.Status.SetConditions(xpv1.ReconcileError(err))
+			return reconcile.Result{}, r.updateStatus(ctx, slo, err)
+		}
+		if !ready {
+			pending = append(pending, c.GetName())
+		}
+	}
+	if err := r.prune(ctx, slo, keep); err != nil {
+		slo.Status.SetConditions(xpv1.ReconcileError(err))
+		return reconcile.Result{}, r.updateStatus(ctx, slo, err)
+	}
+
+	slo.Status.SetConditions(xpv1.ReconcileSuccess())
+	if len(pending) > 0 {
+		slo.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf("Waiting for %s to become ready", strings.Join(pending, ", "))))
+	} else {
+		slo.Status.SetConditions(xpv1.Available())
+	}
+
+	next := r.observeBudget(ctx, o)
+	return reconcile.Result{RequeueAfter: next}, r.updateStatus(ctx, slo, nil)
+}
+
+// apply creates or updates a generated resource and reports whether it is
+// ready.
+func (r *reconciler) apply(ctx context.Context, slo *v1beta1.ServiceLevelObjective, desired child) (bool, error) {
+	existing := emptyLike(desired)
+	created, err := children.Apply(ctx, r.kube, slo, AnnotationSpecHash, desired, existing, func() {
+		copySpec(existing, desired)
+	})
+	if created || err != nil {
+		return false, err
+	}
+	return existing.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue, nil
+}
+
+func emptyLike(c child) child {
+	if _, ok := c.(*dashboardv1beta1.Dashboard); ok {
+		return &dashboardv1beta1.Dashboard{}
+	}
+	return &alertv1beta1.Alert{}
+}
+
+func copySpec(dst, src child) {
+	switch d := dst.(type) {
+	case *alertv1beta1.Alert:
+		d.Spec = src.(*alertv1beta1.Alert).Spec
+	case *dashboardv1beta1.Dashboard:
+		d.Spec = src.(*dashboardv1beta1.Dashboard).Spec
+	}
+}
+
+// prune deletes generated resources that aren't kept, such as those
+// generated under names the objective no longer uses.
+func (r *reconciler) prune(ctx context.Context, slo *v1beta1.ServiceLevelObjective, keep map[string]bool) error {
+	opts := []client.ListOption{client.InNamespace(slo.GetNamespace()), client.MatchingLabels{LabelSLO: slo.GetName()}}
+	if err := children.Prune(ctx, r.kube, slo, &alertv1beta1.AlertList{}, keep, opts...); err != nil {
+		return err
+	}
+	return children.Prune(ctx, r.kube, slo, &dashboardv1beta1.DashboardList{}, keep, opts...)
+}
+
+// observeBudget queries the burn rate and remaining error budget, at most
+// once per poll interval, and returns when to query them next.
+func (r *reconciler) observeBudget(ctx context.Context, o *objective) time.Duration {
+	slo := o.slo
+	now := r.now()
+	if last := slo.Status.LastEvaluated; last != nil && r.pollInterval > 0 {
+		if wait := last.Add(r.pollInterval).Sub(now); wait > 0 {
+			return wait
+		}
+	}
+
+	burnRate, remaining, err := r.queryBudget(ctx, o, now)
+	if err != nil {
+		slo.Status.SetConditions(xpv1.Condition{
+			Type:               TypeBudgetObserved,
+			Status:             corev1.ConditionFalse,
+			Reason:             ReasonQueryFailed,
+			Message:            err.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+		return r.pollInterval
+	}
+	slo.Status.BurnRate = &burnRate
+	slo.Status.ErrorBudgetRemaining = &remaining
+	slo.Status.LastEvaluated = &metav1.Time{Time: now}
+	slo.Status.SetConditions(xpv1.Condition{
+		Type:               TypeBudgetObserved,
+		Status:             corev1.ConditionTrue,
+		Reason:             ReasonBudgetQueried,
+		LastTransitionTime: metav1.Now(),
+	})
+	return r.pollInterval
+}
+
+// queryBudget returns the burn rate over the fast-burn window and the
+// percentage of error budget left over the objective's window.
+func (r *reconciler) queryBudget(ctx context.Context, o *objective, now time.Time) (float64, float64, error) {
+	q, err := r.newQuerier(ctx, r.kube, o.slo)
+	if err != nil {
+		return 0, 0, err
+	}
+	burnRate, err := r.queryScalar(ctx, q, o, tiers[0].long, o.burn, now)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, errQueryBurnRate)
+	}
+	remaining, err := r.queryScalar(ctx, q, o, o.window, o.remaining, now)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, errQueryBudget)
+	}
+	return roundTo(burnRate, 4), roundTo(remaining, 2), nil
+}
+
+func (r *reconciler) queryScalar(ctx context.Context, q querier, o *objective, w time.Duration, expr func(string) string, now time.Time) (float64, error) {
+	query, name := o.query(w, expr)
+	// PromQL queries carry their own range.
+	return q.QueryScalar(ctx, &clients.QueryRangeRequest{
+		Start:          now.Add(-time.Minute).UnixMilli(),
+		End:            now.UnixMilli(),
+		CompositeQuery: map[string]interface{}{"queries": alert.QueryEnvelopes(query)},
+	}, name)
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
+
+// updateStatus writes the objective's status, returning err if it was
+// written successfully.
+func (r *reconciler) updateStatus(ctx context.Context, slo *v1beta1.ServiceLevelObjective, err error) error {
+	if uerr := r.kube.Status().Update(ctx, slo); uerr != nil {
+		return errors.Wrap(uerr, errUpdateStatus)
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slo
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	"github.com/pkg/errors"
+	alertv1beta1 "github.com/rossigee/provider-signoz/apis/alert/v1beta1"
+	dashboardv1beta1 "github.com/rossigee/provider-signoz/apis/dashboard/v1beta1"
+	"github.com/rossigee/provider-signoz/apis/slo/v1beta1"
+	"github.com/rossigee/provider-signoz/internal/clients"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// fakeQuerier answers burn rate queries with burnRate and error budget
+// queries with remaining.
+type fakeQuerier struct {
+	burnRate, remaining float64
+	err                 error
+	calls               int
+}
+
+func (q *fakeQuerier) QueryScalar(_ context.Context, req *clients.QueryRangeRequest, _ string) (float64, error) {
+	q.calls++
+	if q.err != nil {
+		return 0, q.err
+	}
+	queries := req.CompositeQuery["queries"].([]interface{})
+	query := queries[0].(map[string]interface{})["spec"].(map[string]interface{})["query"].(string)
+	if strings.HasSuffix(query, "* 100") {
+		return q.remaining, nil
+	}
+	return q.burnRate, nil
+}
+
+func newFakeKube(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	s := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		v1beta1.SchemeBuilder.AddToScheme,
+		alertv1beta1.SchemeBuilder.AddToScheme,
+		dashboardv1beta1.SchemeBuilder.AddToScheme,
+	} {
+		if err := add(s); err != nil {
+			t.Fatalf("cannot build scheme: %v", err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).WithStatusSubresource(&v1beta1.ServiceLevelObjective{}).Build()
+}
+
+func reconcileSLO(t *testing.T, r *reconciler) *v1beta1.ServiceLevelObjective {
+	t.Helper()
+	key := types.NamespacedName{Namespace: "monitoring", Name: "checkout"}
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &v1beta1.ServiceLevelObjective{}
+	if err := r.kube.Get(context.Background(), key, got); err != nil {
+		t.Fatalf("cannot get objective: %v", err)
+	}
+	return got
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	kube := newFakeKube(t, availability())
+	q := &fakeQuerier{burnRate: 2.5, remaining: 87.5}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	r := &reconciler{
+		kube:         kube,
+		newQuerier:   func(context.Context, client.Client, *v1beta1.ServiceLevelObjective) (querier, error) { return q, nil },
+		pollInterval: time.Minute,
+		now:          func() time.Time { return now },
+	}
+
+	got := reconcileSLO(t, r)
+	for _, name := range []string{"checkout-fast-burn", "checkout-slow-burn"} {
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: name}, &alertv1beta1.Alert{}); err != nil {
+			t.Errorf("expected Alert %s: %v", name, err)
+		}
+	}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: "monitoring", Name: "checkout-error-budget"}, &dashboardv1beta1.Dashboard{}); err != nil {
+		t.Errorf("expected the error budget Dashboard: %v", err)
+	}
+	if got.Status.BurnRate == nil || *got.Status.BurnRate != 2.5 || *got.Status.ErrorBudgetRemaining != 87.5 {
+		t.Errorf("burn rate, budget = %v, %v, want 2.5, 87.5", got.Status.BurnRate, got.Status.ErrorBudgetRemaining)
+	}
+	if c := got.Status.GetCondition(xpv1.TypeReady); c.Status != corev1.ConditionFalse {
+		t.Errorf("Ready = %s, want False until the generated resources are ready", c.Status)
+	}
+
+	// The budget is queried at most once per poll interval.
+	calls := q.calls
+	got = reconcileSLO(t, r)
+	if q.calls != calls {
+		t.Errorf("queried again within the poll interval")
+	}
+}
+
+func TestReconcileQueryFailure(t *testing.T) {
+	kube := newFakeKube(t, availability())
+	r := &reconciler{
+		kube: kube,
+		newQuerier: func(context.Context, client.Client, *v1beta1.ServiceLevelObjective) (querier, error) {
+			return &fakeQuerier{err: errors.New("boom")}, nil
+		},
+		now: time.Now,
+	}
+
+	got := reconcileSLO(t, r)
+	if c := got.Status.GetCondition(TypeBudgetObserved); c.Status != corev1.ConditionFalse || c.Reason != ReasonQueryFailed {
+		t.Errorf("BudgetObserved = %s/%s, want False/%s", c.Status, c.Reason, ReasonQueryFailed)
+	}
+	if c := got.Status.GetCondition(xpv1.TypeSynced); c.Status != corev1.ConditionTrue {
+		t.Errorf("Synced = %s, want True: the resources were still generated", c.Status)
+	}
+}